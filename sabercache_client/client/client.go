@@ -15,6 +15,8 @@ import (
 type Client struct {
 	consistenthash *consistenthash.Consistency
 	peers          []string
+	group          string // 请求的缓存组, 为空时使用服务端的默认组
+	namespace      string // 请求的命名空间, 为空时使用默认命名空间
 }
 
 func NewClient() *Client {
@@ -25,6 +27,21 @@ func NewClient() *Client {
 	c.consistenthash.Register(peers)
 	return c
 }
+
+// Group 返回访问缓存组 group 默认命名空间的 Client, 与 c 共享节点信息
+func (c *Client) Group(group string) *Client {
+	gc := *c
+	gc.group, gc.namespace = group, ""
+	return &gc
+}
+
+// Namespace 返回访问当前缓存组中命名空间 namespace 的 Client, 与 c 共享节点信息
+func (c *Client) Namespace(namespace string) *Client {
+	nc := *c
+	nc.namespace = namespace
+	return &nc
+}
+
 func (c *Client) Get(key string) ([]byte, error) {
 	cli, err := clientv3.New(defaultEtcdConfig)
 	if err != nil {
@@ -41,7 +58,9 @@ func (c *Client) Get(key string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	resp, err := grpcClient.Get(ctx, &pb.GetRequest{
-		Key: key,
+		Key:       key,
+		Group:     c.group,
+		Namespace: c.namespace,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not get %s from peer %s", key, peer)
@@ -50,6 +69,40 @@ func (c *Client) Get(key string) ([]byte, error) {
 	return resp.GetValue(), nil
 }
 
+// MultiGet 按节点分组批量获取, 不存在的Key不出现在返回结果中
+func (c *Client) MultiGet(keys []string) (map[string][]byte, error) {
+	cli, err := clientv3.New(defaultEtcdConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	groups := make(map[string][]string)
+	for _, key := range keys {
+		peer := c.consistenthash.GetPeer(key)
+		groups[peer] = append(groups[peer], key)
+	}
+	values := make(map[string][]byte, len(keys))
+	for peer, keys := range groups {
+		conn, err := EtcdDial(cli, peer)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		grpcClient := pb.NewSaberCacheClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := grpcClient.MultiGet(ctx, &pb.MultiGetRequest{Keys: keys, Group: c.group, Namespace: c.namespace})
+		if err != nil {
+			return nil, fmt.Errorf("could not get %d keys from peer %s", len(keys), peer)
+		}
+		log.Printf("get %d keys from %s\n", len(keys), peer)
+		for _, kv := range resp.Kv {
+			values[kv.Key] = kv.Value
+		}
+	}
+	return values, nil
+}
+
 func (c *Client) GetAll() (respKV []*pb.KeyValue, err error) {
 	cli, err := clientv3.New(defaultEtcdConfig)
 	if err != nil {
@@ -65,7 +118,7 @@ func (c *Client) GetAll() (respKV []*pb.KeyValue, err error) {
 		grpcClient := pb.NewSaberCacheClient(conn)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		resp, err := grpcClient.GetAll(ctx, &pb.GetAllRequest{})
+		resp, err := grpcClient.GetAll(ctx, &pb.GetAllRequest{Group: c.group, Namespace: c.namespace})
 		if err != nil {
 			return nil, fmt.Errorf("could not getall from peer %s", peer)
 		}
@@ -77,6 +130,20 @@ func (c *Client) GetAll() (respKV []*pb.KeyValue, err error) {
 }
 
 func (c *Client) Set(key string, value []byte, ttl int64) (bool, error) {
+	return c.set(key, value, ttl, pb.TimeUnit_SECOND, false)
+}
+
+// PSet 写入Key并设置毫秒级过期时间, pttl 为 -1 时永不过期
+func (c *Client) PSet(key string, value []byte, pttl int64) (bool, error) {
+	return c.set(key, value, pttl, pb.TimeUnit_MILLISECOND, false)
+}
+
+// SetSliding 写入Key并设置滑动过期时间, Key连续 ttl 秒未被读取后过期
+func (c *Client) SetSliding(key string, value []byte, ttl int64) (bool, error) {
+	return c.set(key, value, ttl, pb.TimeUnit_SECOND, true)
+}
+
+func (c *Client) set(key string, value []byte, ttl int64, unit pb.TimeUnit, sliding bool) (bool, error) {
 	cli, err := clientv3.New(defaultEtcdConfig)
 	if err != nil {
 		return false, err
@@ -92,9 +159,13 @@ func (c *Client) Set(key string, value []byte, ttl int64) (bool, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	resp, err := grpcClient.Set(ctx, &pb.SetRequest{
-		Key:   key,
-		Value: value,
-		Ttl:   ttl,
+		Key:       key,
+		Value:     value,
+		Ttl:       ttl,
+		Unit:      unit,
+		Sliding:   sliding,
+		Group:     c.group,
+		Namespace: c.namespace,
 	})
 	if err != nil {
 		return false, fmt.Errorf("could not set %s to peer %s", key, peer)
@@ -103,7 +174,40 @@ func (c *Client) Set(key string, value []byte, ttl int64) (bool, error) {
 	return resp.Ok, nil
 }
 
+// Delete 删除Key, 返回Key是否存在
+func (c *Client) Delete(key string) (bool, error) {
+	cli, err := clientv3.New(defaultEtcdConfig)
+	if err != nil {
+		return false, err
+	}
+	defer cli.Close()
+	peer := c.consistenthash.GetPeer(key)
+	conn, err := EtcdDial(cli, peer)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	grpcClient := pb.NewSaberCacheClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	resp, err := grpcClient.Delete(ctx, &pb.DeleteRequest{Key: key, Group: c.group, Namespace: c.namespace})
+	if err != nil {
+		return false, fmt.Errorf("could not delete %s from peer %s", key, peer)
+	}
+	log.Printf("delete %s from %s\n", key, peer)
+	return resp.Ok, nil
+}
+
 func (c *Client) TTL(key string) (int64, error) {
+	return c.ttl(key, pb.TimeUnit_SECOND)
+}
+
+// PTTL 返回Key剩余的毫秒数
+func (c *Client) PTTL(key string) (int64, error) {
+	return c.ttl(key, pb.TimeUnit_MILLISECOND)
+}
+
+func (c *Client) ttl(key string, unit pb.TimeUnit) (int64, error) {
 	cli, err := clientv3.New(defaultEtcdConfig)
 	if err != nil {
 		return -2, err
@@ -119,11 +223,17 @@ func (c *Client) TTL(key string) (int64, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	resp, err := grpcClient.TTL(ctx, &pb.TTLRequest{
-		Key: key,
+		Key:       key,
+		Unit:      unit,
+		Group:     c.group,
+		Namespace: c.namespace,
 	})
 	if err != nil {
 		return -2, fmt.Errorf("could not set %s to peer %s", key, peer)
 	}
+	if resp.Negative {
+		log.Printf("%s not found in data source, negative cached\n", key)
+	}
 	log.Printf("get ttl %s from %s\n", key, peer)
 	return resp.Ttl, nil
 }
@@ -143,7 +253,7 @@ func (c *Client) Save() (ok bool, err error) {
 		grpcClient := pb.NewSaberCacheClient(conn)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		resp, err := grpcClient.Save(ctx, &pb.SaveRequest{})
+		resp, err := grpcClient.Save(ctx, &pb.SaveRequest{Group: c.group})
 		if !resp.Ok || err != nil {
 			return false, fmt.Errorf("could not save peer %s", peer)
 		}
@@ -152,3 +262,55 @@ func (c *Client) Save() (ok bool, err error) {
 
 	return true, nil
 }
+
+// Stats 返回各节点的缓存占用与容量上限
+func (c *Client) Stats() (stats map[string]*pb.StatsResponse, err error) {
+	cli, err := clientv3.New(defaultEtcdConfig)
+	if err != nil {
+		return nil, err
+	}
+	defer cli.Close()
+	stats = make(map[string]*pb.StatsResponse)
+	for _, peer := range c.peers {
+		conn, err := EtcdDial(cli, peer)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Close()
+		grpcClient := pb.NewSaberCacheClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := grpcClient.Stats(ctx, &pb.StatsRequest{Group: c.group, Namespace: c.namespace})
+		if err != nil {
+			return nil, fmt.Errorf("could not get stats from peer %s", peer)
+		}
+		log.Printf("stats from %s\n", peer)
+		stats[peer] = resp
+	}
+	return
+}
+
+// SetStrategy 将所有节点切换到新的淘汰策略
+func (c *Client) SetStrategy(strategy string) (ok bool, err error) {
+	cli, err := clientv3.New(defaultEtcdConfig)
+	if err != nil {
+		return
+	}
+	defer cli.Close()
+	for _, peer := range c.peers {
+		conn, err := EtcdDial(cli, peer)
+		if err != nil {
+			return false, err
+		}
+		defer conn.Close()
+		grpcClient := pb.NewSaberCacheClient(conn)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := grpcClient.SetStrategy(ctx, &pb.SetStrategyRequest{Strategy: strategy, Group: c.group})
+		if err != nil || !resp.Ok {
+			return false, fmt.Errorf("could not set strategy %s to peer %s", strategy, peer)
+		}
+		log.Printf("set strategy %s -> %s to %s\n", resp.Previous, strategy, peer)
+	}
+	return true, nil
+}
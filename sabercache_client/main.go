@@ -37,6 +37,8 @@ func main() {
 func process(conn net.Conn) {
 	var resp []byte
 	defer conn.Close()
+	// 每个连接单独选择缓存组
+	cli := c
 	for {
 		reader := bufio.NewReader(conn)
 		var buf [128]byte
@@ -47,12 +49,24 @@ func process(conn net.Conn) {
 		}
 		cmd := strings.Split(string(buf[:n]), " ")
 		switch {
+		case cmd[0] == "group" && len(cmd) == 2:
+			cli = c.Group(cmd[1])
+			resp = []byte("true")
+		case cmd[0] == "select" && len(cmd) == 2:
+			cli = cli.Namespace(cmd[1])
+			resp = []byte("true")
+		case cmd[0] == "select":
+			// 不带参数时回到默认命名空间
+			cli = cli.Namespace("")
+			resp = []byte("true")
 		case cmd[0] == "get" && len(cmd) != 1:
-			resp = Get(cmd[1])
+			resp = Get(cli, cmd[1])
+		case cmd[0] == "mget" && len(cmd) != 1:
+			resp = MultiGet(cli, cmd[1:])
 		case cmd[0] == "getall":
-			resp = GetAll()
+			resp = GetAll(cli)
 		case cmd[0] == "set" && len(cmd) == 3:
-			if Set(cmd[1], []byte(cmd[2]), -1) {
+			if Set(cli, cmd[1], []byte(cmd[2]), -1) {
 				resp = []byte("true")
 			} else {
 				resp = []byte("false")
@@ -60,18 +74,62 @@ func process(conn net.Conn) {
 		case cmd[0] == "set" && len(cmd) == 4:
 			ttl, err := strconv.Atoi(cmd[2])
 			if err != nil {
+				// TTL 格式错误时不写入
 				log.Println(err)
-				resp = []byte("err!")
+				conn.Write([]byte("err!"))
+				continue
 			}
-			if Set(cmd[1], []byte(cmd[3]), int64(ttl)) {
+			if Set(cli, cmd[1], []byte(cmd[3]), int64(ttl)) {
+				resp = []byte("true")
+			} else {
+				resp = []byte("false")
+			}
+		case cmd[0] == "sset" && len(cmd) == 4:
+			ttl, err := strconv.Atoi(cmd[2])
+			if err != nil {
+				// TTL 格式错误时不写入
+				log.Println(err)
+				conn.Write([]byte("err!"))
+				continue
+			}
+			if SetSliding(cli, cmd[1], []byte(cmd[3]), int64(ttl)) {
+				resp = []byte("true")
+			} else {
+				resp = []byte("false")
+			}
+		case cmd[0] == "pset" && len(cmd) == 4:
+			pttl, err := strconv.Atoi(cmd[2])
+			if err != nil {
+				// TTL 格式错误时不写入
+				log.Println(err)
+				conn.Write([]byte("err!"))
+				continue
+			}
+			if PSet(cli, cmd[1], []byte(cmd[3]), int64(pttl)) {
+				resp = []byte("true")
+			} else {
+				resp = []byte("false")
+			}
+		case cmd[0] == "del" && len(cmd) == 2:
+			if Delete(cli, cmd[1]) {
 				resp = []byte("true")
 			} else {
 				resp = []byte("false")
 			}
 		case cmd[0] == "ttl" && len(cmd) != 1:
-			resp = []byte(fmt.Sprint(TTL(cmd[1])))
+			resp = []byte(fmt.Sprint(TTL(cli, cmd[1])))
+		case cmd[0] == "pttl" && len(cmd) != 1:
+			resp = []byte(fmt.Sprint(PTTL(cli, cmd[1])))
+		case cmd[0] == "stats":
+			resp = Stats(cli)
+		case cmd[0] == "strategy" && len(cmd) == 2:
+			if SetStrategy(cli, cmd[1]) {
+				resp = []byte("true")
+			} else {
+				resp = []byte("false")
+			}
 		case cmd[0] == "save" && len(cmd) != 1:
-			if Save() {
+			if Save(cli) {
 				resp = []byte("true")
 			} else {
 				resp = []byte("false")
@@ -85,7 +143,7 @@ func process(conn net.Conn) {
 	}
 }
 
-func Get(key string) (value []byte) {
+func Get(c *client.Client, key string) (value []byte) {
 	value, err := c.Get(key)
 	if err != nil {
 		log.Println(err)
@@ -93,7 +151,21 @@ func Get(key string) (value []byte) {
 	}
 	return
 }
-func GetAll() []byte {
+func MultiGet(c *client.Client, keys []string) []byte {
+	var str string
+	values, err := c.MultiGet(keys)
+	if err != nil {
+		log.Println(err)
+		return []byte("err!")
+	}
+	for _, key := range keys {
+		if v, ok := values[key]; ok {
+			str += key + " : " + string(v) + "\n"
+		}
+	}
+	return []byte(str)
+}
+func GetAll(c *client.Client) []byte {
 	var str string
 	KeyValue, err := c.GetAll()
 	if err != nil {
@@ -105,7 +177,7 @@ func GetAll() []byte {
 	}
 	return []byte(str)
 }
-func Set(key string, value []byte, ttl int64) (ok bool) {
+func Set(c *client.Client, key string, value []byte, ttl int64) (ok bool) {
 	ok, err := c.Set(key, value, ttl)
 	if !ok && err != nil {
 		log.Println(err)
@@ -113,7 +185,31 @@ func Set(key string, value []byte, ttl int64) (ok bool) {
 	}
 	return
 }
-func TTL(key string) int64 {
+func SetSliding(c *client.Client, key string, value []byte, ttl int64) (ok bool) {
+	ok, err := c.SetSliding(key, value, ttl)
+	if !ok && err != nil {
+		log.Println(err)
+		return
+	}
+	return
+}
+func PSet(c *client.Client, key string, value []byte, pttl int64) (ok bool) {
+	ok, err := c.PSet(key, value, pttl)
+	if !ok && err != nil {
+		log.Println(err)
+		return
+	}
+	return
+}
+func Delete(c *client.Client, key string) (ok bool) {
+	ok, err := c.Delete(key)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	return
+}
+func TTL(c *client.Client, key string) int64 {
 	ttl, err := c.TTL(key)
 	if err != nil {
 		log.Println(err)
@@ -121,7 +217,48 @@ func TTL(key string) int64 {
 	}
 	return ttl
 }
-func Save() bool {
+func PTTL(c *client.Client, key string) int64 {
+	pttl, err := c.PTTL(key)
+	if err != nil {
+		log.Println(err)
+		return pttl
+	}
+	return pttl
+}
+func Stats(c *client.Client) []byte {
+	var str string
+	stats, err := c.Stats()
+	if err != nil {
+		log.Println(err)
+		return []byte("err!")
+	}
+	for peer, st := range stats {
+		str += fmt.Sprintf("%s : group %s, strategy %s, bytes %d/%d, entries %d/%d, overhead %d, evictions %v, ghost hits %d (%d bytes), stale hits %d, refreshes %d (%d failed)\n",
+			peer, st.Group, st.Strategy, st.Bytes, st.MaxBytes, st.Entries, st.MaxEntries, st.Overhead, st.Evictions, st.GhostHits, st.GhostHitBytes,
+			st.StaleHits, st.Refreshes, st.RefreshFailures)
+		str += fmt.Sprintf("%s : negative hits %d, negative entries %d\n", peer, st.NegativeHits, st.NegativeEntries)
+		str += fmt.Sprintf("%s : write queue %d, flushed %d, coalesced %d, retries %d, failures %d, rejected %d\n",
+			peer, st.WriteQueueDepth, st.WritesFlushed, st.WritesCoalesced, st.WriteRetries, st.WriteFailures, st.WritesRejected)
+		str += fmt.Sprintf("%s : breaker %s, requests %d, failures %d, opens %d, rejected %d, load retries %d\n",
+			peer, st.BreakerState, st.BreakerRequests, st.BreakerFailures, st.BreakerOpens, st.BreakerRejected, st.LoadRetries)
+		for ns, nst := range st.Namespaces {
+			if ns == "" {
+				ns = "(default)"
+			}
+			str += fmt.Sprintf("%s : namespace %s, bytes %d/%d, entries %d, evictions %d\n", peer, ns, nst.Bytes, nst.MaxBytes, nst.Entries, nst.Evictions)
+		}
+	}
+	return []byte(str)
+}
+func SetStrategy(c *client.Client, strategy string) bool {
+	ok, err := c.SetStrategy(strategy)
+	if err != nil {
+		log.Println(err)
+		return ok
+	}
+	return ok
+}
+func Save(c *client.Client) bool {
 	ok, err := c.Save()
 	if err != nil {
 		log.Println(err)
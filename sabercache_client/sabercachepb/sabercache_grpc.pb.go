@@ -21,11 +21,15 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	SaberCache_Get_FullMethodName    = "/sabercachepb.SaberCache/Get"
-	SaberCache_GetAll_FullMethodName = "/sabercachepb.SaberCache/GetAll"
-	SaberCache_Set_FullMethodName    = "/sabercachepb.SaberCache/Set"
-	SaberCache_TTL_FullMethodName    = "/sabercachepb.SaberCache/TTL"
-	SaberCache_Save_FullMethodName   = "/sabercachepb.SaberCache/Save"
+	SaberCache_Get_FullMethodName         = "/sabercachepb.SaberCache/Get"
+	SaberCache_GetAll_FullMethodName      = "/sabercachepb.SaberCache/GetAll"
+	SaberCache_MultiGet_FullMethodName    = "/sabercachepb.SaberCache/MultiGet"
+	SaberCache_Set_FullMethodName         = "/sabercachepb.SaberCache/Set"
+	SaberCache_TTL_FullMethodName         = "/sabercachepb.SaberCache/TTL"
+	SaberCache_Save_FullMethodName        = "/sabercachepb.SaberCache/Save"
+	SaberCache_Stats_FullMethodName       = "/sabercachepb.SaberCache/Stats"
+	SaberCache_SetStrategy_FullMethodName = "/sabercachepb.SaberCache/SetStrategy"
+	SaberCache_Delete_FullMethodName      = "/sabercachepb.SaberCache/Delete"
 )
 
 // SaberCacheClient is the client API for SaberCache service.
@@ -34,9 +38,13 @@ const (
 type SaberCacheClient interface {
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	GetAll(ctx context.Context, in *GetAllRequest, opts ...grpc.CallOption) (*GetAllResponse, error)
+	MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error)
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
 	TTL(ctx context.Context, in *TTLRequest, opts ...grpc.CallOption) (*TTLResponse, error)
 	Save(ctx context.Context, in *SaveRequest, opts ...grpc.CallOption) (*SaveResponse, error)
+	Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error)
+	SetStrategy(ctx context.Context, in *SetStrategyRequest, opts ...grpc.CallOption) (*SetStrategyResponse, error)
+	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 }
 
 type saberCacheClient struct {
@@ -65,6 +73,15 @@ func (c *saberCacheClient) GetAll(ctx context.Context, in *GetAllRequest, opts .
 	return out, nil
 }
 
+func (c *saberCacheClient) MultiGet(ctx context.Context, in *MultiGetRequest, opts ...grpc.CallOption) (*MultiGetResponse, error) {
+	out := new(MultiGetResponse)
+	err := c.cc.Invoke(ctx, SaberCache_MultiGet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *saberCacheClient) Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error) {
 	out := new(SetResponse)
 	err := c.cc.Invoke(ctx, SaberCache_Set_FullMethodName, in, out, opts...)
@@ -92,15 +109,46 @@ func (c *saberCacheClient) Save(ctx context.Context, in *SaveRequest, opts ...gr
 	return out, nil
 }
 
+func (c *saberCacheClient) Stats(ctx context.Context, in *StatsRequest, opts ...grpc.CallOption) (*StatsResponse, error) {
+	out := new(StatsResponse)
+	err := c.cc.Invoke(ctx, SaberCache_Stats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saberCacheClient) SetStrategy(ctx context.Context, in *SetStrategyRequest, opts ...grpc.CallOption) (*SetStrategyResponse, error) {
+	out := new(SetStrategyResponse)
+	err := c.cc.Invoke(ctx, SaberCache_SetStrategy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *saberCacheClient) Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error) {
+	out := new(DeleteResponse)
+	err := c.cc.Invoke(ctx, SaberCache_Delete_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SaberCacheServer is the server API for SaberCache service.
 // All implementations must embed UnimplementedSaberCacheServer
 // for forward compatibility
 type SaberCacheServer interface {
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error)
+	MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error)
 	Set(context.Context, *SetRequest) (*SetResponse, error)
 	TTL(context.Context, *TTLRequest) (*TTLResponse, error)
 	Save(context.Context, *SaveRequest) (*SaveResponse, error)
+	Stats(context.Context, *StatsRequest) (*StatsResponse, error)
+	SetStrategy(context.Context, *SetStrategyRequest) (*SetStrategyResponse, error)
+	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	mustEmbedUnimplementedSaberCacheServer()
 }
 
@@ -114,6 +162,9 @@ func (UnimplementedSaberCacheServer) Get(context.Context, *GetRequest) (*GetResp
 func (UnimplementedSaberCacheServer) GetAll(context.Context, *GetAllRequest) (*GetAllResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
 }
+func (UnimplementedSaberCacheServer) MultiGet(context.Context, *MultiGetRequest) (*MultiGetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MultiGet not implemented")
+}
 func (UnimplementedSaberCacheServer) Set(context.Context, *SetRequest) (*SetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Set not implemented")
 }
@@ -123,6 +174,15 @@ func (UnimplementedSaberCacheServer) TTL(context.Context, *TTLRequest) (*TTLResp
 func (UnimplementedSaberCacheServer) Save(context.Context, *SaveRequest) (*SaveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Save not implemented")
 }
+func (UnimplementedSaberCacheServer) Stats(context.Context, *StatsRequest) (*StatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Stats not implemented")
+}
+func (UnimplementedSaberCacheServer) SetStrategy(context.Context, *SetStrategyRequest) (*SetStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetStrategy not implemented")
+}
+func (UnimplementedSaberCacheServer) Delete(context.Context, *DeleteRequest) (*DeleteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
 func (UnimplementedSaberCacheServer) mustEmbedUnimplementedSaberCacheServer() {}
 
 // UnsafeSaberCacheServer may be embedded to opt out of forward compatibility for this service.
@@ -172,6 +232,24 @@ func _SaberCache_GetAll_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SaberCache_MultiGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MultiGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaberCacheServer).MultiGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaberCache_MultiGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaberCacheServer).MultiGet(ctx, req.(*MultiGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _SaberCache_Set_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SetRequest)
 	if err := dec(in); err != nil {
@@ -226,6 +304,60 @@ func _SaberCache_Save_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SaberCache_Stats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaberCacheServer).Stats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaberCache_Stats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaberCacheServer).Stats(ctx, req.(*StatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaberCache_SetStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaberCacheServer).SetStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaberCache_SetStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaberCacheServer).SetStrategy(ctx, req.(*SetStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SaberCache_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SaberCacheServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SaberCache_Delete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SaberCacheServer).Delete(ctx, req.(*DeleteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SaberCache_ServiceDesc is the grpc.ServiceDesc for SaberCache service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +373,10 @@ var SaberCache_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAll",
 			Handler:    _SaberCache_GetAll_Handler,
 		},
+		{
+			MethodName: "MultiGet",
+			Handler:    _SaberCache_MultiGet_Handler,
+		},
 		{
 			MethodName: "Set",
 			Handler:    _SaberCache_Set_Handler,
@@ -253,6 +389,18 @@ var SaberCache_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Save",
 			Handler:    _SaberCache_Save_Handler,
 		},
+		{
+			MethodName: "Stats",
+			Handler:    _SaberCache_Stats_Handler,
+		},
+		{
+			MethodName: "SetStrategy",
+			Handler:    _SaberCache_SetStrategy_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _SaberCache_Delete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "sabercache.proto",
@@ -0,0 +1,212 @@
+package sabercache_server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrCircuitOpen 熔断器打开期间不再回源, 直接返回该错误
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// BreakerState 熔断器状态
+type BreakerState int
+
+const (
+	BreakerClosed   BreakerState = iota // 正常回源, 统计失败率
+	BreakerOpen                         // 拒绝回源, 等待 openInterval 后进入半开
+	BreakerHalfOpen                     // 放行少量探测请求, 全部成功后关闭, 任一失败重新打开
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// BreakerStats 熔断器状态与统计, 未启用熔断器时 State 始终为 closed
+type BreakerStats struct {
+	State    BreakerState
+	Requests int64 // 当前统计窗口内的回源次数
+	Failures int64 // 当前统计窗口内的失败次数
+	Opens    int64 // 熔断器打开的次数
+	Rejected int64 // 熔断器打开期间被拒绝的回源次数
+}
+
+// WithCircuitBreaker 回源失败率熔断: 每个 window 内回源次数不少于 minRequests 且失败率达到 failureRate 时打开熔断器,
+// 打开期间回源直接返回 ErrCircuitOpen; openInterval 后进入半开状态, 最多放行 halfOpenProbes 个探测请求,
+// 全部成功后关闭, 任一失败重新打开。ErrNotFound 与调用方取消或超时不计为失败
+func WithCircuitBreaker(failureRate float64, minRequests int, window, openInterval time.Duration, halfOpenProbes int) Option {
+	return func(sc *SaberCache) {
+		if failureRate <= 0 {
+			return
+		}
+		if halfOpenProbes <= 0 {
+			halfOpenProbes = 1
+		}
+		sc.breaker = &breaker{
+			failureRate:    failureRate,
+			minRequests:    int64(minRequests),
+			window:         window,
+			openInterval:   openInterval,
+			halfOpenProbes: halfOpenProbes,
+			windowStart:    time.Now(),
+		}
+	}
+}
+
+// WithRetry 回源失败时间隔 backoff(逐次翻倍, 不超过 maxBackoff)最多重试 maxRetries 次,
+// ErrNotFound、熔断器打开与调用方 ctx 结束时不再重试
+func WithRetry(maxRetries int, backoff, maxBackoff time.Duration) Option {
+	return func(sc *SaberCache) {
+		sc.maxRetries, sc.backoff, sc.maxBackoff = maxRetries, backoff, maxBackoff
+	}
+}
+
+type breaker struct {
+	failureRate    float64
+	minRequests    int64
+	window         time.Duration
+	openInterval   time.Duration
+	halfOpenProbes int
+	mu             sync.Mutex
+	state          BreakerState
+	generation     uint64    // 每次状态变化时加一, 只统计在当前状态下放行的回源
+	windowStart    time.Time // closed 状态下统计窗口的起点
+	openedAt       time.Time
+	probes         int // half-open 状态下已放行的探测请求数
+	successes      int // half-open 状态下成功的探测请求数
+	stats          BreakerStats
+}
+
+// allow 判断是否可以回源, 返回 nil 时调用方需在回源结束后以返回的 generation 调用 done
+func (b *breaker) allow() (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	switch b.state {
+	case BreakerOpen:
+		if now.Sub(b.openedAt) < b.openInterval {
+			b.stats.Rejected++
+			return 0, ErrCircuitOpen
+		}
+		b.setState(BreakerHalfOpen)
+		b.probes, b.successes = 0, 0
+		fallthrough
+	case BreakerHalfOpen:
+		if b.probes >= b.halfOpenProbes {
+			b.stats.Rejected++
+			return 0, ErrCircuitOpen
+		}
+		b.probes++
+	default:
+		if b.window > 0 && now.Sub(b.windowStart) >= b.window {
+			b.windowStart, b.stats.Requests, b.stats.Failures = now, 0, 0
+		}
+	}
+	return b.generation, nil
+}
+
+// done 记录一次回源结果, 调用方取消或超时的回源、以及放行后熔断器状态已变化的回源不计入统计
+func (b *breaker) done(generation uint64, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if generation != b.generation {
+		return
+	}
+	canceled := errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+	failed := err != nil && !errors.Is(err, ErrNotFound)
+	switch b.state {
+	case BreakerHalfOpen:
+		if canceled {
+			// 归还探测名额
+			b.probes--
+			return
+		}
+		if failed {
+			b.open()
+			return
+		}
+		b.successes++
+		if b.successes >= b.halfOpenProbes {
+			b.setState(BreakerClosed)
+			b.windowStart, b.stats.Requests, b.stats.Failures = time.Now(), 0, 0
+		}
+	case BreakerClosed:
+		if canceled {
+			return
+		}
+		b.stats.Requests++
+		if failed {
+			b.stats.Failures++
+		}
+		if b.stats.Requests >= b.minRequests && float64(b.stats.Failures) >= b.failureRate*float64(b.stats.Requests) {
+			b.open()
+		}
+	}
+}
+
+func (b *breaker) open() {
+	b.setState(BreakerOpen)
+	b.openedAt = time.Now()
+	b.stats.Opens++
+}
+
+func (b *breaker) setState(state BreakerState) {
+	b.state = state
+	b.generation++
+}
+
+func (b *breaker) Stats() BreakerStats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := b.stats
+	stats.State = b.state
+	return stats
+}
+
+// retrieve 经熔断器与重试调用 fn 回源
+func (sc *SaberCache) retrieve(ctx context.Context, fn func() error) error {
+	backoff := sc.backoff
+	for attempt := 0; ; attempt++ {
+		var generation uint64
+		if sc.breaker != nil {
+			var err error
+			if generation, err = sc.breaker.allow(); err != nil {
+				return err
+			}
+		}
+		err := fn()
+		if sc.breaker != nil {
+			sc.breaker.done(generation, err)
+		}
+		if err == nil || errors.Is(err, ErrNotFound) || attempt >= sc.maxRetries || ctx.Err() != nil {
+			return err
+		}
+		atomic.AddInt64(&sc.loadStats.Retries, 1)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		if backoff *= 2; sc.maxBackoff > 0 && backoff > sc.maxBackoff {
+			backoff = sc.maxBackoff
+		}
+	}
+}
+
+// BreakerStats 返回回源熔断器的状态与统计
+func (sc *SaberCache) BreakerStats() BreakerStats {
+	if sc.breaker == nil {
+		return BreakerStats{}
+	}
+	return sc.breaker.Stats()
+}
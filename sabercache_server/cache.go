@@ -7,43 +7,190 @@ import (
 	"log"
 	"os"
 	"sabercache_server/cachememory"
+	"sabercache_server/util"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
 type Cache struct {
+	mu            sync.RWMutex // 保护 cachememory 与 cacheStrategy, 切换淘汰策略时持有写锁
 	cachememory   cachememory.CacheMemory
-	capacity      int64
+	capacity      int64 // 容量上限(Byte), 0 表示不限制
+	maxEntries    int   // Key数量上限, 0 表示不限制
 	cacheStrategy string
+	ghost         *cachememory.GhostList // 为 nil 时不记录被淘汰的Key
+	evictions     [4]int64               // 按 EvictReason 统计的移除次数
+	backup        string                 // 备份文件名, 每个缓存组单独备份
+	quotas        map[string]int64       // 命名空间的字节配额, 非空时 cachememory 为 NamespacedCache
+	nsEvictions   sync.Map               // 命名空间 -> 因容量不足被淘汰的次数(*int64)
+	loaded        sync.Map               // 由 Retriever 从数据源取回的Key, 被写入、删除、过期或淘汰时清除
 	stop          chan struct{}
+	stopOnce      sync.Once
+}
+
+// NamespaceStats 命名空间的占用与容量上限, 以及因容量不足被淘汰的次数
+type NamespaceStats struct {
+	cachememory.Stats
+	Evictions int64
+}
+
+// EvictStats 按原因统计的移除次数与幽灵列表的命中情况
+type EvictStats struct {
+	Evictions map[string]int64
+	Ghost     cachememory.GhostStats
 }
 
 func newCache(capacity int64, cacheStrategy string) *Cache {
 	c := &Cache{
 		capacity:      capacity,
+		maxEntries:    util.MaxEntries,
 		cacheStrategy: cacheStrategy,
+		backup:        "backup.txt",
+		quotas:        make(map[string]int64),
+		stop:          make(chan struct{}),
+	}
+	if util.GhostEntries > 0 {
+		c.ghost = cachememory.NewGhostList(util.GhostEntries)
+	}
+	c.cachememory = c.newCacheMemory(cacheStrategy)
+	if c.maxEntries > 0 {
+		c.cachememory.SetLimit(c.capacity, c.maxEntries)
+	}
+	if util.MemoryAccounting == "overhead" {
+		overhead := util.EntryOverhead
+		if overhead <= 0 {
+			overhead = c.cachememory.EstimateOverhead()
+		}
+		c.cachememory.SetOverhead(overhead)
+	}
+	if util.HeapLimit > 0 {
+		// 水位保护作用于 Cache 本身, 切换淘汰策略后依然有效
+		guardHeap(c)
+	}
+	return c
+}
+
+var (
+	heapGuardMu sync.Mutex
+	heapGuard   *cachememory.HeapGuard // 进程内所有缓存组共用一个水位保护
+)
+
+// guardHeap 将缓存加入进程的水位保护, 首个缓存加入时启动
+func guardHeap(c *Cache) {
+	heapGuardMu.Lock()
+	defer heapGuardMu.Unlock()
+	if heapGuard == nil {
+		heapGuard = cachememory.NewHeapGuard(uint64(util.HeapLimit), 0)
+	}
+	heapGuard.Add(c)
+}
+
+// unguardHeap 将缓存移出进程的水位保护, 最后一个缓存移出时停止
+func unguardHeap(c *Cache) {
+	heapGuardMu.Lock()
+	defer heapGuardMu.Unlock()
+	if heapGuard != nil && heapGuard.Remove(c) == 0 {
+		heapGuard.Stop()
+		heapGuard = nil
+	}
+}
+
+// Stop 停止水位保护、定时备份与过期监控, 之后不应再使用该缓存
+func (c *Cache) Stop() {
+	c.stopOnce.Do(func() {
+		unguardHeap(c)
+		close(c.stop)
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+		c.cachememory.Stop()
+	})
+}
+
+// newCacheMemory 按配置的分片数创建 CacheMemory, 设置了命名空间配额时每个命名空间单独创建
+func (c *Cache) newCacheMemory(cacheStrategy string) cachememory.CacheMemory {
+	newPartition := c.newPartition(cacheStrategy)
+	if len(c.quotas) == 0 {
+		return newPartition(c.capacity)
+	}
+	cm := cachememory.NewNamespacedCache(c.capacity, newPartition)
+	for ns, quota := range c.quotas {
+		// 配额在 SetNamespaceQuota 中已校验
+		cm.SetQuota(ns, quota)
+	}
+	return cm
+}
+
+// newPartition 返回按容量创建单个 CacheMemory 的函数
+func (c *Cache) newPartition(cacheStrategy string) func(maxBytes int64) cachememory.CacheMemory {
+	return func(maxBytes int64) cachememory.CacheMemory {
+		if util.Shards > 1 {
+			return cachememory.NewShardedCache(util.Shards, maxBytes, func(maxBytes int64) cachememory.CacheMemory {
+				return newCacheMemory(maxBytes, cacheStrategy, c.onEliminated)
+			})
+		}
+		return newCacheMemory(maxBytes, cacheStrategy, c.onEliminated)
 	}
+}
+
+// onEliminated 按原因与命名空间统计移除次数, 被移除的Key清除取回标记, 因容量不足被淘汰的Key记入幽灵列表, 过期或删除的Key移出幽灵列表
+func (c *Cache) onEliminated(key string, value cachememory.Value, reason cachememory.EvictReason) {
+	atomic.AddInt64(&c.evictions[reason], 1)
+	if reason != cachememory.EvictReplaced {
+		c.loaded.Delete(key)
+	}
+	if reason == cachememory.EvictCapacity {
+		ns, _ := cachememory.SplitNamespace(key)
+		n, _ := c.nsEvictions.LoadOrStore(ns, new(int64))
+		atomic.AddInt64(n.(*int64), 1)
+	}
+	if c.ghost == nil {
+		return
+	}
+	switch reason {
+	case cachememory.EvictCapacity:
+		c.ghost.Add(key, int64(len(key)+value.Len()))
+	case cachememory.EvictExpired, cachememory.EvictDeleted:
+		c.ghost.Remove(key)
+	}
+}
+
+// strategies 支持的淘汰策略
+var strategies = map[string]bool{"lfu": true, "fifo": true, "lru": true, "arc": true, "tinylfu": true, "gds": true, "clock": true, "s3fifo": true}
+
+// newCacheMemory 根据淘汰策略创建 CacheMemory
+func newCacheMemory(capacity int64, cacheStrategy string, callback cachememory.OnEliminated) cachememory.CacheMemory {
 	switch {
-	case c.cacheStrategy == "lfu":
-		c.cachememory = cachememory.NewLFUCache(c.capacity, nil)
-	case c.cacheStrategy == "fifo":
-		c.cachememory = cachememory.NewFIFOCache(c.capacity, nil)
-	case c.cacheStrategy == "lru":
-		c.cachememory = cachememory.NewLRUCache(c.capacity, nil)
+	case cacheStrategy == "lfu":
+		return cachememory.NewLFUCacheWithDecay(capacity, time.Duration(util.LFUDecayPeriod)*time.Second, callback)
+	case cacheStrategy == "fifo":
+		return cachememory.NewFIFOCache(capacity, callback)
+	case cacheStrategy == "lru":
+		return cachememory.NewLRUCache(capacity, callback)
+	case cacheStrategy == "arc":
+		return cachememory.NewARCCache(capacity, callback)
+	case cacheStrategy == "tinylfu":
+		return cachememory.NewTinyLFUCache(capacity, util.TinyLFUResetPeriod, callback)
+	case cacheStrategy == "gds":
+		return cachememory.NewGDSCache(capacity, callback)
+	case cacheStrategy == "clock":
+		return cachememory.NewClockCache(capacity, callback)
+	case cacheStrategy == "s3fifo":
+		return cachememory.NewS3FIFOCache(capacity, callback)
 	default:
-		c.cachememory = cachememory.NewLFUCache(c.capacity, nil)
+		return cachememory.NewLFUCacheWithDecay(capacity, time.Duration(util.LFUDecayPeriod)*time.Second, callback)
 	}
-	return c
 }
 func (c *Cache) Init() bool {
-	file, error := os.Open("../file/backup.txt")
+	file, error := os.Open("../file/" + c.backup)
 	defer file.Close()
 	if error != nil {
 		log.Println(error)
 		return false
 	}
-	now := time.Now().Unix()
+	now := time.Now().UnixMilli()
 	reader := bufio.NewReader(file)
 	for {
 		str, err := reader.ReadString('\n')
@@ -62,28 +209,115 @@ func (c *Cache) Init() bool {
 			log.Println(err)
 			return false
 		}
+		// 备份文件中的到期时间为Unix秒
 		if expireTime == -1 {
 			c.SetWithoutTTL(strs[0], ByteView{[]byte(strs[1])})
-		} else if int64(expireTime) > now {
-			c.SetWithTTL(strs[0], ByteView{[]byte(strs[1])}, int64(expireTime))
+		} else if int64(expireTime)*1000 > now {
+			c.SetWithPTTL(strs[0], ByteView{[]byte(strs[1])}, int64(expireTime)*1000-now)
 		}
 	}
 	return true
 }
 func (c *Cache) SetWithoutTTL(key string, value ByteView) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defer c.loaded.Delete(key)
+	c.unghost(key)
 	c.cachememory.SetWithoutTTL(key, value)
 }
 
 func (c *Cache) SetWithTTL(key string, value ByteView, ttl int64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defer c.loaded.Delete(key)
+	c.unghost(key)
 	c.cachememory.SetWithTTL(key, value, ttl)
 }
+
+func (c *Cache) SetWithPTTL(key string, value ByteView, pttl int64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defer c.loaded.Delete(key)
+	c.unghost(key)
+	c.cachememory.SetWithPTTL(key, value, pttl)
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次读取命中都将到期时间顺延 pttl 毫秒
+func (c *Cache) SetWithSlidingPTTL(key string, value ByteView, pttl int64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defer c.loaded.Delete(key)
+	c.unghost(key)
+	c.cachememory.SetWithSlidingPTTL(key, value, pttl)
+}
+
+// SetWithPTTLCost 写入Key并附带重新计算代价, pttl 为 -1 时永不过期, 淘汰策略不支持代价感知时忽略 cost
+func (c *Cache) SetWithPTTLCost(key string, value ByteView, pttl int64, cost int64) {
+	defer c.loaded.Delete(key)
+	c.setWithPTTLCost(key, value, pttl, cost)
+}
+
+// SetLoaded 与 SetWithPTTLCost 相同, 并将Key标记为从数据源取回; 先标记再写入,
+// 并发的 Set 在写入后清除标记, 因此客户端写入的值不会带有标记
+func (c *Cache) SetLoaded(key string, value ByteView, pttl int64, cost int64) {
+	c.loaded.Store(key, struct{}{})
+	c.setWithPTTLCost(key, value, pttl, cost)
+}
+
+// Loaded 返回Key当前的值是否从数据源取回
+func (c *Cache) Loaded(key string) bool {
+	_, ok := c.loaded.Load(key)
+	return ok
+}
+
+func (c *Cache) setWithPTTLCost(key string, value ByteView, pttl int64, cost int64) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.unghost(key)
+	cs, ok := c.cachememory.(cachememory.CostSetter)
+	switch {
+	case ok && pttl == -1:
+		cs.SetWithoutTTLCost(key, value, cost)
+	case ok:
+		cs.SetWithPTTLCost(key, value, pttl, cost)
+	case pttl == -1:
+		c.cachememory.SetWithoutTTL(key, value)
+	default:
+		c.cachememory.SetWithPTTL(key, value, pttl)
+	}
+}
+
+// unghost Key重新写入缓存时移出幽灵列表, 之后的未命中不再计为本可命中
+func (c *Cache) unghost(key string) {
+	if c.ghost != nil {
+		c.ghost.Remove(key)
+	}
+}
+
 func (c *Cache) Get(key string) (ByteView, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if v, ok := c.cachememory.Get(key); ok {
-		return v.(ByteView), true
+		if view, ok := v.(ByteView); ok {
+			return view, true
+		}
+	}
+	if c.ghost != nil {
+		c.ghost.Hit(key)
 	}
 	return ByteView{}, false
 }
+
+// Delete 删除Key, 返回Key是否存在
+func (c *Cache) Delete(key string) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	defer c.loaded.Delete(key)
+	return c.cachememory.Delete(key)
+}
 func (c *Cache) GetAll() (kv []*cachememory.Entity) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if c.cachememory == nil {
 		return []*cachememory.Entity{}
 	}
@@ -92,25 +326,174 @@ func (c *Cache) GetAll() (kv []*cachememory.Entity) {
 }
 
 func (c *Cache) TTL(key string) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if c.cachememory == nil {
 		return -2
 	}
 	return c.cachememory.TTL(key)
 }
 
+func (c *Cache) PTTL(key string) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.cachememory == nil {
+		return -2
+	}
+	return c.cachememory.PTTL(key)
+}
+
+func (c *Cache) Stats() cachememory.Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.cachememory == nil {
+		return cachememory.Stats{}
+	}
+	return c.cachememory.Stats()
+}
+
+// EvictStats 返回按原因统计的移除次数与幽灵列表命中情况
+func (c *Cache) EvictStats() EvictStats {
+	stats := EvictStats{Evictions: make(map[string]int64)}
+	for reason := range c.evictions {
+		stats.Evictions[cachememory.EvictReason(reason).String()] = atomic.LoadInt64(&c.evictions[reason])
+	}
+	if c.ghost != nil {
+		stats.Ghost = c.ghost.Stats()
+	}
+	return stats
+}
+
+// SetLimit 调整容量上限, 供 HeapGuard 调用
+func (c *Cache) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	c.cachememory.SetLimit(maxBytes, maxEntries)
+}
+
+// Strategy 返回当前的淘汰策略
+func (c *Cache) Strategy() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cacheStrategy
+}
+
+// SetStrategy 切换淘汰策略。
+// 持有写锁期间按当前的容量上限与每Key开销创建新的 CacheMemory, 将未过期的Key连同剩余过期时间迁移过去后替换旧实例,
+// 期间的读写请求会等待迁移完成而不会丢失。新策略无法保留旧策略的访问历史, 容量不足时按新策略淘汰。
+func (c *Cache) SetStrategy(cacheStrategy string) error {
+	if !strategies[cacheStrategy] {
+		return fmt.Errorf("unknown cache strategy %s", cacheStrategy)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cacheStrategy == c.cacheStrategy {
+		return nil
+	}
+	stats := c.cachememory.Stats()
+	cm := c.newCacheMemory(cacheStrategy)
+	cm.SetLimit(stats.MaxBytes, stats.MaxEntries)
+	cm.SetOverhead(stats.Overhead)
+	c.migrate(cm)
+	c.cacheStrategy = cacheStrategy
+	return nil
+}
+
+// migrate 将未过期的Key连同剩余过期时间迁移到 cm 后替换旧实例, 需持有写锁
+func (c *Cache) migrate(cm cachememory.CacheMemory) {
+	old := c.cachememory
+	now := time.Now().UnixMilli()
+	for _, kv := range old.GetAll() {
+		if kv.ExpiredTime == -1 {
+			cm.SetWithoutTTL(kv.Key, kv.Value)
+		} else if kv.Sliding > 0 {
+			// 滑动过期的Key迁移视为一次访问, 重新计时
+			cm.SetWithSlidingPTTL(kv.Key, kv.Value, kv.Sliding)
+		} else if kv.ExpiredTime > now {
+			cm.SetWithPTTL(kv.Key, kv.Value, kv.ExpiredTime-now)
+		}
+	}
+	old.Stop()
+	c.cachememory = cm
+}
+
+// SetNamespaceQuota 设置命名空间 ns 的字节配额, 命名空间不存在时创建; 配额从总容量中划出,
+// 各配额之和需小于总容量。首次设置配额时已有的Key迁移到 NamespacedCache 中
+func (c *Cache) SetNamespaceQuota(ns string, quota int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cm, ok := c.cachememory.(*cachememory.NamespacedCache); ok {
+		if err := cm.SetQuota(ns, quota); err != nil {
+			return err
+		}
+		c.quotas[ns] = quota
+		return nil
+	}
+	stats := c.cachememory.Stats()
+	cm := cachememory.NewNamespacedCache(stats.MaxBytes, c.newPartition(c.cacheStrategy))
+	if err := cm.SetQuota(ns, quota); err != nil {
+		cm.Stop()
+		return err
+	}
+	cm.SetLimit(stats.MaxBytes, stats.MaxEntries)
+	cm.SetOverhead(stats.Overhead)
+	c.quotas[ns] = quota
+	c.migrate(cm)
+	return nil
+}
+
+// HasNamespace 默认命名空间(空字符串)始终存在
+func (c *Cache) HasNamespace(ns string) bool {
+	if ns == "" {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.quotas[ns]
+	return ok
+}
+
+// NamespaceStats 返回各命名空间的统计, 默认命名空间的键为空字符串
+func (c *Cache) NamespaceStats() map[string]NamespaceStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := make(map[string]NamespaceStats)
+	if cm, ok := c.cachememory.(*cachememory.NamespacedCache); ok {
+		for ns, st := range cm.NamespaceStats() {
+			stats[ns] = NamespaceStats{Stats: st}
+		}
+	} else {
+		stats[""] = NamespaceStats{Stats: c.cachememory.Stats()}
+	}
+	for ns, st := range stats {
+		if n, ok := c.nsEvictions.Load(ns); ok {
+			st.Evictions = atomic.LoadInt64(n.(*int64))
+			stats[ns] = st
+		}
+	}
+	return stats
+}
+
 func (c *Cache) Save() bool {
-	entitys := c.cachememory.GetAll()
-	file, error := os.OpenFile("./backup/backup.txt", os.O_WRONLY|os.O_CREATE, 0766)
+	entitys := c.GetAll()
+	file, error := os.OpenFile("./backup/"+c.backup, os.O_WRONLY|os.O_CREATE, 0766)
 	defer file.Close()
 	if error != nil {
 		log.Println(error)
 		return false
 	}
 	writer := bufio.NewWriter(file)
-	now := time.Now().Unix()
+	now := time.Now().UnixMilli()
 	for _, kv := range entitys {
-		if kv.ExpiredTime == -1 || kv.ExpiredTime-now >= 30 {
-			writer.WriteString(fmt.Sprintf("%s %s %d\n", kv.Key, kv.Value.(ByteView).String(), kv.ExpiredTime))
+		view, ok := kv.Value.(ByteView)
+		if !ok {
+			continue
+		}
+		if kv.ExpiredTime == -1 {
+			writer.WriteString(fmt.Sprintf("%s %s %d\n", kv.Key, view.String(), kv.ExpiredTime))
+			writer.Flush()
+		} else if kv.ExpiredTime-now >= 30*1000 {
+			writer.WriteString(fmt.Sprintf("%s %s %d\n", kv.Key, view.String(), kv.ExpiredTime/1000))
 			writer.Flush()
 		}
 	}
@@ -0,0 +1,425 @@
+package cachememory
+
+import (
+	"container/list"
+	"sabercache_server/cachememory/generic"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// ARCCache 自适应替换缓存(Adaptive Replacement Cache)
+// t1 保存只访问过一次的Key, t2 保存访问过至少两次的Key,
+// b1/b2 分别记录最近从 t1/t2 淘汰的Key(只记录Key与大小, 不保存Value),
+// 命中 b1/b2 时动态调整 t1 的目标容量 p, 从而在"最近"与"频繁"之间自适应。
+// 容量按 len(key)+Value.Len() 以字节计。
+type ARCCache struct {
+	capacity   int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries int   // Key 数量上限, 0 表示不限制
+	overhead   int64 // 每个Key额外计入的字节数
+	length     int64 // Cache 当前容量(Byte), 即 t1Len+t2Len
+	p          int64 // t1 的目标容量(Byte)
+	t1Len      int64
+	t2Len      int64
+	b1Len      int64
+	b2Len      int64
+	t1         *list.List // 链头表示最近使用
+	t2         *list.List
+	b1         *list.List
+	b2         *list.List
+	hashmap    map[string]*list.Element // 缓存中的Key
+	ghostmap   map[string]*list.Element // 幽灵列表中的Key
+	wheel      *TimingWheel
+	mu         sync.Mutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	callback   OnEliminated
+}
+
+type arcEntry struct {
+	entity *Entity
+	inT2   bool
+}
+
+type arcGhost struct {
+	key  string
+	size int64
+	inB2 bool
+}
+
+func NewARCCache(maxBytes int64, callback OnEliminated) *ARCCache {
+	c := &ARCCache{
+		capacity: maxBytes,
+		t1:       list.New(),
+		t2:       list.New(),
+		b1:       list.New(),
+		b2:       list.New(),
+		hashmap:  make(map[string]*list.Element),
+		ghostmap: make(map[string]*list.Element),
+		wheel:    NewTimingWheel(DefaultTick, SystemClock),
+		callback: callback,
+		stop:     make(chan struct{}),
+	}
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+// Get 从缓存获取对应Key的Value, 命中后将Key移入 t2 链头
+func (c *ARCCache) Get(key string) (Value, bool) {
+	c.mu.Lock()
+	if elem, ok := c.hashmap[key]; ok {
+		entry := elem.Value.(*arcEntry)
+		if entry.entity.ExpiredTime != -1 && entry.entity.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.Unlock()
+			c.RemoveExpiredKey(key)
+			return nil, false
+		}
+		slideExpire(entry.entity)
+		c.promote(elem)
+		c.mu.Unlock()
+		return entry.entity.Value, true
+	}
+	c.mu.Unlock()
+	return nil, false
+}
+
+func (c *ARCCache) GetAll() (kv []*Entity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, elem := range c.hashmap {
+		entity := elem.Value.(*arcEntry).entity
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, entity)
+	}
+	return
+}
+
+func (c *ARCCache) SetWithoutTTL(key string, value Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0) {
+		c.wheel.Remove(key)
+	}
+}
+
+func (c *ARCCache) SetWithTTL(key string, value Value, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *ARCCache) SetWithPTTL(key string, value Value, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *ARCCache) SetWithSlidingPTTL(key string, value Value, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *ARCCache) set(key string, value Value, expireTime, sliding int64) bool {
+	kvSize := int64(len(key)) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	if elem, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值, 视为再次访问
+		entity := elem.Value.(*arcEntry).entity
+		c.detach(elem)
+		for c.overflow(kvSize, 1) {
+			c.replace(false)
+		}
+		old := entity.Value
+		entity.Value = value
+		entity.ExpiredTime, entity.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		c.attach(&arcEntry{entity: entity, inT2: true})
+		return true
+	}
+	entity := &Entity{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding}
+	if elem, ok := c.ghostmap[key]; ok {
+		// 命中幽灵列表, 调整 t1 目标容量后直接放入 t2
+		ghost := elem.Value.(*arcGhost)
+		if ghost.inB2 {
+			c.p -= max64(c.b1Len/max64(c.b2Len, 1), 1) * kvSize
+			if c.p < 0 {
+				c.p = 0
+			}
+		} else {
+			c.p += max64(c.b2Len/max64(c.b1Len, 1), 1) * kvSize
+			if limit := c.limit(); c.p > limit {
+				c.p = limit
+			}
+		}
+		c.removeGhost(elem)
+		for c.overflow(kvSize, 1) {
+			c.replace(ghost.inB2)
+		}
+		c.attach(&arcEntry{entity: entity, inT2: true})
+		return true
+	}
+	// 新增缓存Key
+	for c.overflow(kvSize, 1) {
+		c.replace(false)
+	}
+	c.attach(&arcEntry{entity: entity})
+	c.trimGhost()
+	return true
+}
+
+// promote 将缓存中的Key移入 t2 链头
+func (c *ARCCache) promote(elem *list.Element) {
+	entry := elem.Value.(*arcEntry)
+	if entry.inT2 {
+		c.t2.MoveToFront(elem)
+		return
+	}
+	c.detach(elem)
+	entry.inT2 = true
+	c.attach(entry)
+}
+
+func (c *ARCCache) attach(entry *arcEntry) {
+	size := int64(len(entry.entity.Key)) + int64(entry.entity.Value.Len()) + c.overhead
+	if entry.inT2 {
+		c.hashmap[entry.entity.Key] = c.t2.PushFront(entry)
+		c.t2Len += size
+	} else {
+		c.hashmap[entry.entity.Key] = c.t1.PushFront(entry)
+		c.t1Len += size
+	}
+	c.length += size
+}
+
+func (c *ARCCache) detach(elem *list.Element) {
+	entry := elem.Value.(*arcEntry)
+	size := int64(len(entry.entity.Key)) + int64(entry.entity.Value.Len()) + c.overhead
+	if entry.inT2 {
+		c.t2.Remove(elem)
+		c.t2Len -= size
+	} else {
+		c.t1.Remove(elem)
+		c.t1Len -= size
+	}
+	delete(c.hashmap, entry.entity.Key)
+	c.length -= size
+}
+
+// replace 按照目标容量 p 从 t1 或 t2 淘汰一枚缓存到对应的幽灵列表
+func (c *ARCCache) replace(inB2 bool) {
+	var elem *list.Element
+	if c.t1.Len() > 0 && (c.t1Len > c.p || (inB2 && c.t1Len == c.p) || c.t2.Len() == 0) {
+		elem = c.t1.Back()
+	} else {
+		elem = c.t2.Back()
+	}
+	if elem == nil {
+		return
+	}
+	entry := elem.Value.(*arcEntry)
+	k, v := entry.entity.Key, entry.entity.Value
+	c.detach(elem)
+	c.wheel.Remove(k)
+	ghost := &arcGhost{key: k, size: int64(len(k)) + int64(v.Len()) + c.overhead, inB2: entry.inT2}
+	if ghost.inB2 {
+		c.ghostmap[k] = c.b2.PushFront(ghost)
+		c.b2Len += ghost.size
+	} else {
+		c.ghostmap[k] = c.b1.PushFront(ghost)
+		c.b1Len += ghost.size
+	}
+	// 移除后的善后处理
+	if c.callback != nil {
+		c.callback(k, v, EvictCapacity)
+	}
+}
+
+func (c *ARCCache) removeGhost(elem *list.Element) {
+	ghost := elem.Value.(*arcGhost)
+	if ghost.inB2 {
+		c.b2.Remove(elem)
+		c.b2Len -= ghost.size
+	} else {
+		c.b1.Remove(elem)
+		c.b1Len -= ghost.size
+	}
+	delete(c.ghostmap, ghost.key)
+}
+
+// trimGhost 限制幽灵列表大小: t1+b1 不超过容量上限, 全部列表不超过2倍容量上限
+func (c *ARCCache) trimGhost() {
+	for c.b1.Len() > 0 && c.exceed(c.t1Len+c.b1Len, c.t1.Len()+c.b1.Len(), 1) {
+		c.removeGhost(c.b1.Back())
+	}
+	for c.b2.Len() > 0 && c.exceed(c.length+c.b1Len+c.b2Len, c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len(), 2) {
+		c.removeGhost(c.b2.Back())
+	}
+}
+
+// exceed bytes 字节、entries 个Key是否超过 times 倍的容量上限
+func (c *ARCCache) exceed(bytes int64, entries int, times int) bool {
+	return (c.capacity > 0 && bytes > int64(times)*c.capacity) ||
+		(c.maxEntries > 0 && entries > times*c.maxEntries)
+}
+
+// overflow 再写入 size 字节、n 个Key后是否超出容量上限
+func (c *ARCCache) overflow(size int64, n int) bool {
+	return c.exceed(c.length+size, c.t1.Len()+c.t2.Len()+n, 1)
+}
+
+// limit t1 目标容量 p 的上限, 不限制字节数时取当前占用
+func (c *ARCCache) limit() int64 {
+	if c.capacity > 0 {
+		return c.capacity
+	}
+	return c.length
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *ARCCache) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+
+// RemoveExpiredKey 移除过期Key, 过期Key不进入幽灵列表
+func (c *ARCCache) RemoveExpiredKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在, 被删除的Key不进入幽灵列表
+func (c *ARCCache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *ARCCache) MultiDeleteKey(keys []string, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *ARCCache) removeKey(key string, reason EvictReason) bool {
+	elem, ok := c.hashmap[key]
+	if !ok {
+		return false
+	}
+	entity := elem.Value.(*arcEntry).entity
+	k, v := entity.Key, entity.Value
+	c.detach(elem)
+	c.wheel.Remove(k)
+	if c.callback != nil {
+		c.callback(k, v, reason)
+	}
+	return true
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *ARCCache) expireKey(key string) {
+	if elem, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, elem.Value.(*arcEntry).entity) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+// Remove 按ARC策略淘汰一枚缓存
+func (c *ARCCache) Remove() {
+	c.replace(false)
+}
+
+func (c *ARCCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t1.Len() + c.t2.Len()
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *ARCCache) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	if limit := c.limit(); c.p > limit {
+		c.p = limit
+	}
+	for c.overflow(0, 0) {
+		c.replace(false)
+	}
+	c.trimGhost()
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰
+func (c *ARCCache) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delta := overhead - c.overhead
+	c.t1Len += delta * int64(c.t1.Len())
+	c.t2Len += delta * int64(c.t2.Len())
+	c.length = c.t1Len + c.t2Len
+	c.overhead = overhead
+	for c.overflow(0, 0) {
+		c.replace(false)
+	}
+	c.trimGhost()
+}
+
+// EstimateOverhead 估算每个Key在链表节点、arcEntry、Entity 与 map 中的额外开销, 不含幽灵列表
+func (c *ARCCache) EstimateOverhead() int64 {
+	return generic.EntryOverhead[string, Value](unsafe.Sizeof(arcEntry{}))
+}
+
+func (c *ARCCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Bytes: c.length, Entries: c.t1.Len() + c.t2.Len(), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+
+func (c *ARCCache) TTL(key string) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *ARCCache) PTTL(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		return remainingPTTL(elem.Value.(*arcEntry).entity.ExpiredTime)
+	}
+	return -2
+}
+
+func (c *ARCCache) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *ARCCache) Stop() {
+	c.Close()
+}
+
+func max64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+var _ CacheMemory = (*ARCCache)(nil)
@@ -0,0 +1,139 @@
+package cachememory
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestARCGet(t *testing.T) {
+	var cache CacheMemory = NewARCCache(int64(1024), nil)
+	defer cache.Stop()
+	t.Run("SetWithoutTTL", func(t *testing.T) {
+		cache.SetWithoutTTL("key1", String("1234"))
+		if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+			t.Fatalf("cache hit key1=1234 failed")
+		}
+	})
+	t.Run("SetWithTTL", func(t *testing.T) {
+		cache.SetWithTTL("key1", String("1234"), 10)
+		if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+			t.Fatalf("cache hit key1=1234 failed")
+		}
+	})
+	t.Run("GetNilKey", func(t *testing.T) {
+		if _, ok := cache.Get("key2"); ok {
+			t.Fatalf("cache miss key2 failed")
+		}
+	})
+}
+
+func TestARCGetAll(t *testing.T) {
+	var cache CacheMemory = NewARCCache(int64(1024), nil)
+	defer cache.Stop()
+	cache.SetWithoutTTL("key1", String("value1"))
+	cache.SetWithTTL("key2", String("value2"), 10)
+	if entity := cache.GetAll(); len(entity) != 2 {
+		t.Fatalf("getall failed!")
+	}
+}
+
+func TestARCTTL(t *testing.T) {
+	var cache CacheMemory = NewARCCache(int64(1024), nil)
+	defer cache.Stop()
+	t.Run("TTLTest", func(t *testing.T) {
+		cache.SetWithTTL("key1", String("1234"), 5)
+		if ttl := cache.TTL("key1"); ttl != 5 {
+			t.Fatalf("ttl test key1 failed")
+		}
+	})
+	t.Run("TTLExpireTest", func(t *testing.T) {
+		cache.SetWithTTL("key1", String("1234"), 2)
+		time.Sleep(2 * time.Second)
+		if ttl := cache.TTL("key1"); ttl != -2 {
+			t.Fatalf("ttl test expiredKey key1 failed")
+		}
+		if _, ok := cache.Get("key1"); ok {
+			t.Fatalf("cache remove expired Key key1 failed")
+		}
+	})
+}
+
+func TestARCRemove(t *testing.T) {
+	k1, k2, k3, k4 := "key1", "key2", "key3", "key4"
+	v1, v2, v3, v4 := "value1", "value2", "value3", "value4"
+	cap := len(k1 + k2 + k3 + v1 + v2 + v3)
+	t.Run("扫描抗性", func(t *testing.T) {
+		var cache CacheMemory = NewARCCache(int64(cap), nil)
+		defer cache.Stop()
+		cache.SetWithoutTTL(k1, String(v1))
+		cache.SetWithoutTTL(k2, String(v2))
+		cache.Get(k1)
+		cache.Get(k2)
+		// k1,k2 进入 t2, 一次性扫描只会淘汰 t1 中的Key
+		cache.SetWithoutTTL(k3, String(v3))
+		cache.SetWithoutTTL(k4, String(v4))
+		if _, ok := cache.Get(k3); ok || cache.Len() != 3 {
+			t.Fatalf("Remove key3 failed")
+		}
+		for _, k := range []string{k1, k2, k4} {
+			if _, ok := cache.Get(k); !ok {
+				t.Fatalf("Get %s failed", k)
+			}
+		}
+	})
+	t.Run("幽灵命中", func(t *testing.T) {
+		var cache CacheMemory = NewARCCache(int64(cap), nil)
+		defer cache.Stop()
+		cache.SetWithoutTTL(k1, String(v1))
+		cache.SetWithoutTTL(k2, String(v2))
+		cache.SetWithoutTTL(k3, String(v3))
+		cache.SetWithoutTTL(k4, String(v4))
+		if _, ok := cache.Get(k1); ok {
+			t.Fatalf("Remove key1 failed")
+		}
+		// k1 命中 b1, 重新写入后直接进入 t2
+		cache.SetWithoutTTL(k1, String(v1))
+		cache.SetWithoutTTL(k3, String(v3))
+		cache.SetWithoutTTL(k4, String(v4))
+		if _, ok := cache.Get(k1); !ok {
+			t.Fatalf("Get key1 failed")
+		}
+	})
+}
+
+func TestARCMaxSize(t *testing.T) {
+	var cache CacheMemory = NewARCCache(int64(4), nil)
+	defer cache.Stop()
+	cache.SetWithoutTTL("key1", String("value1"))
+	if _, ok := cache.Get("key1"); ok {
+		t.Fatalf("Get out of size Key key1")
+	}
+	cache.SetWithTTL("k2", String("v2"), 10)
+	if _, ok := cache.Get("k2"); !ok {
+		t.Fatalf("Get k2 fialed")
+	}
+	cache.SetWithoutTTL("k2", String("value2"))
+	if v2, ok := cache.Get("k2"); !ok || v2 != String("v2") {
+		t.Fatalf("Update out of size Key k2")
+	}
+}
+
+func TestARCOnEnvicted(t *testing.T) {
+	keys := make([]string, 0)
+	callback := func(key string, value Value, reason EvictReason) {
+		keys = append(keys, key)
+	}
+	var cache CacheMemory = NewARCCache(int64(10), callback)
+	defer cache.Stop()
+	cache.SetWithoutTTL("key1", String("123456"))
+	cache.SetWithoutTTL("k2", String("k2"))
+	cache.SetWithoutTTL("k3", String("k3"))
+	cache.SetWithoutTTL("k4", String("k4"))
+
+	expect := []string{"key1", "k2"}
+
+	if !reflect.DeepEqual(expect, keys) {
+		t.Fatalf("Call OnEvicted failed, expect keys equals to %s", expect)
+	}
+}
@@ -1,31 +1,101 @@
 package cachememory
 
+import (
+	"sabercache_server/cachememory/generic"
+	"time"
+)
+
 type CacheMemory interface {
 	Get(key string) (Value, bool)
 	GetAll() []*Entity
 	SetWithoutTTL(key string, value Value)
 	SetWithTTL(key string, value Value, ttl int64)
+	SetWithPTTL(key string, value Value, pttl int64)
+	SetWithSlidingPTTL(key string, value Value, pttl int64)
 	ExpireKeyMonitor()
 	MultiDeleteKey(keys []string, t int64)
 	RemoveExpiredKey(key string)
+	Delete(key string) bool
 	Remove()
 	TTL(key string) int64
+	PTTL(key string) int64
 	Len() int
+	SetLimit(maxBytes int64, maxEntries int)
+	SetOverhead(overhead int64)
+	EstimateOverhead() int64
+	Stats() Stats
 	Stop()
 }
-type Entity struct {
-	Key         string
-	Value       Value
-	ExpiredTime int64
-}
+type Entity = generic.Entity[string, Value]
+
+// Stats 缓存占用与容量上限, 上限为0表示不限制
+type Stats = generic.Stats
 type Value interface {
 	Len() int
 }
 
-const DelChCap int = 100
+type OnEliminated = generic.OnEliminated[string, Value]
+
+// EvictReason Key被移出缓存的原因
+type EvictReason = generic.EvictReason
+
+const (
+	EvictCapacity = generic.EvictCapacity
+	EvictExpired  = generic.EvictExpired
+	EvictDeleted  = generic.EvictDeleted
+	EvictReplaced = generic.EvictReplaced
+)
+
+// GhostList 记录最近因容量不足被淘汰的Key
+type GhostList = generic.GhostList[string]
+
+type GhostStats = generic.GhostStats
+
+func NewGhostList(maxEntries int) *GhostList {
+	return generic.NewGhostList[string](maxEntries)
+}
+
+// hashKey FNV-1a 哈希, 避免 hash/fnv 的内存分配
+func hashKey(key string) uint64 {
+	h := uint64(14695981039346656037)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+// remainingPTTL 根据到期时间(毫秒时间戳)计算剩余毫秒数, -1 表示永不过期, -2 表示已过期
+func remainingPTTL(expiredTime int64) int64 {
+	if expiredTime == -1 {
+		return -1
+	}
+	if pttl := expiredTime - time.Now().UnixMilli(); pttl > 0 {
+		return pttl
+	}
+	return -2
+}
+
+// slideExpire 滑动过期的Key被访问时顺延到期时间, 需持有写锁
+func slideExpire(e *Entity) {
+	if e.Sliding > 0 {
+		e.ExpiredTime = time.Now().UnixMilli() + e.Sliding
+	}
+}
+
+// rearmExpire 时间轮到期时, 滑动过期的Key若期间被访问过则按顺延后的到期时间重新计时, 返回是否重新计时
+func rearmExpire(wheel *TimingWheel, e *Entity) bool {
+	if e.Sliding > 0 && e.ExpiredTime > time.Now().UnixMilli() {
+		wheel.Add(e.Key, time.UnixMilli(e.ExpiredTime))
+		return true
+	}
+	return false
+}
 
-type DelCH struct {
-	Keys []string
-	t    int64
+// pttlToTTL 将剩余毫秒数向上取整为秒
+func pttlToTTL(pttl int64) int64 {
+	if pttl < 0 {
+		return pttl
+	}
+	return (pttl + 999) / 1000
 }
-type OnEliminated func(key string, value Value)
@@ -0,0 +1,233 @@
+package cachememory
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newAllCaches(maxBytes int64, callback OnEliminated) map[string]CacheMemory {
+	return map[string]CacheMemory{
+		"lru":     NewLRUCache(maxBytes, callback),
+		"lfu":     NewLFUCache(maxBytes, callback),
+		"fifo":    NewFIFOCache(maxBytes, callback),
+		"arc":     NewARCCache(maxBytes, callback),
+		"tinylfu": NewTinyLFUCache(maxBytes, 0, callback),
+		"gds":     NewGDSCache(maxBytes, callback),
+		"clock":   NewClockCache(maxBytes, callback),
+		"s3fifo":  NewS3FIFOCache(maxBytes, callback),
+	}
+}
+
+func TestSetWithPTTL(t *testing.T) {
+	for name, cache := range newAllCaches(int64(1024), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			cache.SetWithPTTL("key1", String("value1"), 300)
+			if pttl := cache.PTTL("key1"); pttl <= 0 || pttl > 300 {
+				t.Fatalf("pttl test key1 failed, got %d", pttl)
+			}
+			if ttl := cache.TTL("key1"); ttl != 1 {
+				t.Fatalf("ttl test key1 expect 1, got %d", ttl)
+			}
+			cache.SetWithoutTTL("key2", String("value2"))
+			if pttl := cache.PTTL("key2"); pttl != -1 {
+				t.Fatalf("pttl test key2 failed, got %d", pttl)
+			}
+			if pttl := cache.PTTL("key3"); pttl != -2 {
+				t.Fatalf("pttl test key3 failed, got %d", pttl)
+			}
+			time.Sleep(350 * time.Millisecond)
+			if _, ok := cache.Get("key1"); ok {
+				t.Fatalf("cache remove expired Key key1 failed")
+			}
+			if l := cache.Len(); l != 1 {
+				t.Fatalf("expired key1 not removed, len %d", l)
+			}
+		})
+	}
+}
+
+// TestSlidingExpire 滑动过期的Key每次命中都顺延到期时间, 空闲超时后被主动移除
+func TestSlidingExpire(t *testing.T) {
+	for name, cache := range newAllCaches(int64(1024), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			cache.SetWithSlidingPTTL("idle", String("v"), 200)
+			cache.SetWithSlidingPTTL("busy", String("v"), 200)
+			for i := 0; i < 4; i++ {
+				time.Sleep(100 * time.Millisecond)
+				if _, ok := cache.Get("busy"); !ok {
+					t.Fatalf("busy expired after %d accesses", i)
+				}
+			}
+			// TTL 返回剩余的空闲时间
+			if pttl := cache.PTTL("busy"); pttl <= 150 || pttl > 200 {
+				t.Fatalf("unexpected pttl %d", pttl)
+			}
+			if l := cache.Len(); l != 1 {
+				t.Fatalf("idle key not removed, len %d", l)
+			}
+			// 覆盖写入后恢复按写入时间过期
+			cache.SetWithPTTL("busy", String("v"), 150)
+			time.Sleep(100 * time.Millisecond)
+			cache.Get("busy")
+			time.Sleep(100 * time.Millisecond)
+			if _, ok := cache.Get("busy"); ok {
+				t.Fatalf("busy should expire after overwrite")
+			}
+		})
+	}
+}
+
+// TestExpireKeyMonitor 到期Key无需访问即被主动移除
+func TestExpireKeyMonitor(t *testing.T) {
+	var mu sync.Mutex
+	keys := make(map[EvictReason][]string)
+	callback := func(key string, value Value, reason EvictReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		keys[reason] = append(keys[reason], key)
+	}
+	caches := newAllCaches(int64(1024), callback)
+	for _, cache := range caches {
+		defer cache.Stop()
+		cache.SetWithTTL("key1", String("value1"), 1)
+		cache.SetWithTTL("key2", String("value2"), 1)
+		cache.SetWithoutTTL("key2", String("value2"))
+	}
+	time.Sleep(1500 * time.Millisecond)
+	for _, cache := range caches {
+		if l := cache.Len(); l != 1 {
+			t.Fatalf("expired key not removed, len %d", l)
+		}
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	// key2 被覆盖一次, key1 到期一次
+	if len(keys[EvictExpired]) != len(caches) || len(keys[EvictReplaced]) != len(caches) || len(keys) != 2 {
+		t.Fatalf("expect %d callbacks for each reason, got %v", len(caches), keys)
+	}
+}
+
+// TestEvictReason 回调区分容量淘汰、过期、删除与覆盖
+func TestEvictReason(t *testing.T) {
+	var mu sync.Mutex
+	reasons := make(map[string][]EvictReason)
+	callback := func(key string, value Value, reason EvictReason) {
+		mu.Lock()
+		defer mu.Unlock()
+		reasons[key] = append(reasons[key], reason)
+	}
+	for name, cache := range newAllCaches(int64(0), callback) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			mu.Lock()
+			reasons = make(map[string][]EvictReason)
+			mu.Unlock()
+			cache.SetWithoutTTL("replaced", String("v1"))
+			cache.SetWithoutTTL("replaced", String("v2"))
+			cache.SetWithoutTTL("deleted", String("v"))
+			if !cache.Delete("deleted") || cache.Delete("deleted") {
+				t.Fatalf("delete failed")
+			}
+			cache.SetWithPTTL("expired", String("v"), 10)
+			cache.SetWithoutTTL("capacity", String("v"))
+			time.Sleep(50 * time.Millisecond)
+			cache.SetLimit(0, 1)
+			mu.Lock()
+			defer mu.Unlock()
+			expect := map[string]EvictReason{"replaced": EvictReplaced, "deleted": EvictDeleted, "expired": EvictExpired}
+			for key, reason := range expect {
+				if len(reasons[key]) == 0 || reasons[key][0] != reason {
+					t.Fatalf("%s: expect %v, got %v", key, reason, reasons[key])
+				}
+			}
+			// 容量只够保留一个Key, replaced 与 capacity 之一被淘汰
+			if evicted := append(reasons["capacity"], reasons["replaced"][1:]...); len(evicted) != 1 || evicted[0] != EvictCapacity {
+				t.Fatalf("expect one capacity eviction, got %v", reasons)
+			}
+		})
+	}
+}
+
+func TestMaxEntries(t *testing.T) {
+	for name, cache := range newAllCaches(int64(0), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			cache.SetLimit(0, 3)
+			for i := 0; i < 10; i++ {
+				cache.SetWithoutTTL("key"+strconv.Itoa(i), String("value"))
+			}
+			if l := cache.Len(); l != 3 {
+				t.Fatalf("expect len 3, got %d", l)
+			}
+			stats := cache.Stats()
+			if stats.Entries != 3 || stats.Bytes != 27 || stats.MaxEntries != 3 || stats.MaxBytes != 0 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+		})
+	}
+}
+
+// TestZeroCapacity 容量为0表示不限制
+func TestZeroCapacity(t *testing.T) {
+	for name, cache := range newAllCaches(int64(0), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			for i := 0; i < 100; i++ {
+				cache.SetWithoutTTL("key"+strconv.Itoa(i), String("value"))
+			}
+			if l := cache.Len(); l != 100 {
+				t.Fatalf("expect len 100, got %d", l)
+			}
+		})
+	}
+}
+
+// TestSetLimit 调小容量上限后立即淘汰, 字节与数量上限同时生效
+func TestSetLimit(t *testing.T) {
+	for name, cache := range newAllCaches(int64(1024), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			for i := 0; i < 10; i++ {
+				cache.SetWithoutTTL("key"+strconv.Itoa(i), String("value"))
+			}
+			cache.SetLimit(45, 8)
+			if l := cache.Len(); l != 5 {
+				t.Fatalf("expect len 5, got %d", l)
+			}
+			cache.SetLimit(0, 2)
+			if stats := cache.Stats(); stats.Entries != 2 || stats.Bytes != 18 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+		})
+	}
+}
+
+func TestSetOverhead(t *testing.T) {
+	for name, cache := range newAllCaches(int64(0), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			if o := cache.EstimateOverhead(); o <= 0 {
+				t.Fatalf("expect positive overhead, got %d", o)
+			}
+			cache.SetWithoutTTL("k1", String("v1"))
+			cache.SetWithoutTTL("k2", String("v2"))
+			cache.SetOverhead(100)
+			if stats := cache.Stats(); stats.Bytes != 208 || stats.Overhead != 100 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+			cache.SetLimit(300, 0)
+			cache.SetWithoutTTL("k3", String("v3"))
+			if l := cache.Len(); l != 2 {
+				t.Fatalf("expect len 2, got %d", l)
+			}
+			cache.SetOverhead(0)
+			if stats := cache.Stats(); stats.Bytes != 8 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package cachememory
+
+import "sabercache_server/cachememory/generic"
+
+// ClockCache 以 string 为Key、Value 为值的 generic.ClockCache
+type ClockCache = generic.ClockCache[string, Value]
+
+func NewClockCache(maxBytes int64, callback OnEliminated) *ClockCache {
+	return generic.NewClockCache[string, Value](maxBytes, callback)
+}
+
+var _ CacheMemory = (*ClockCache)(nil)
@@ -177,7 +177,7 @@ func TestFIFOMaxSize(t *testing.T) {
 }
 func TestFIFOOnEnvicted(t *testing.T) {
 	keys := make([]string, 0)
-	callback := func(key string, value Value) {
+	callback := func(key string, value Value, reason EvictReason) {
 		keys = append(keys, key)
 	}
 	var cache CacheMemory = NewFIFOCache(int64(10), callback)
@@ -0,0 +1,375 @@
+package cachememory
+
+import (
+	"container/heap"
+	"container/list"
+	"sabercache_server/cachememory/generic"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// DefaultCost 未指定重新计算代价时的默认代价
+const DefaultCost = 1
+
+// CostSetter 支持携带重新计算代价写入的 CacheMemory, cost 越大越不容易被淘汰
+type CostSetter interface {
+	SetWithoutTTLCost(key string, value Value, cost int64)
+	SetWithPTTLCost(key string, value Value, pttl int64, cost int64)
+}
+
+// GDSCache 代价感知缓存(GreedyDual-Size)
+// 每个Key的优先级 H = L + cost/size, 淘汰时移除 H 最小的Key并将膨胀值 L 提升为该Key的 H,
+// 命中时按当前的 L 重新计算 H。未被访问的Key随 L 的增长逐渐老化,
+// 从而优先淘汰代价低、体积大且久未访问的Key。容量按 len(key)+Value.Len() 以字节计。
+type GDSCache struct {
+	capacity   int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries int   // Key 数量上限, 0 表示不限制
+	overhead   int64 // 每个Key额外计入的字节数
+	length     int64 // Cache 当前容量(Byte)
+	inflation  float64
+	seq        uint64 // 写入与访问序号, 优先级相同时先淘汰较早访问的Key
+	hashmap    map[string]*gdsEntry
+	queue      gdsQueue
+	wheel      *TimingWheel
+	mu         sync.Mutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	callback   OnEliminated
+}
+
+type gdsEntry struct {
+	entity   *Entity
+	cost     int64
+	priority float64
+	seq      uint64
+	index    int // 在堆中的下标
+}
+
+// gdsQueue 按优先级排列的小顶堆
+type gdsQueue []*gdsEntry
+
+func (q gdsQueue) Len() int { return len(q) }
+
+func (q gdsQueue) Less(i, j int) bool {
+	if q[i].priority == q[j].priority {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].priority < q[j].priority
+}
+
+func (q gdsQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *gdsQueue) Push(x any) {
+	entry := x.(*gdsEntry)
+	entry.index = len(*q)
+	*q = append(*q, entry)
+}
+
+func (q *gdsQueue) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return entry
+}
+
+func NewGDSCache(maxBytes int64, callback OnEliminated) *GDSCache {
+	c := &GDSCache{
+		capacity: maxBytes,
+		hashmap:  make(map[string]*gdsEntry),
+		wheel:    NewTimingWheel(DefaultTick, SystemClock),
+		callback: callback,
+		stop:     make(chan struct{}),
+	}
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+// Get 从缓存获取对应Key的Value, 命中后按当前膨胀值重新计算优先级
+func (c *GDSCache) Get(key string) (Value, bool) {
+	c.mu.Lock()
+	if entry, ok := c.hashmap[key]; ok {
+		if entry.entity.ExpiredTime != -1 && entry.entity.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.Unlock()
+			c.RemoveExpiredKey(key)
+			return nil, false
+		}
+		slideExpire(entry.entity)
+		c.touch(entry)
+		c.mu.Unlock()
+		return entry.entity.Value, true
+	}
+	c.mu.Unlock()
+	return nil, false
+}
+
+func (c *GDSCache) GetAll() (kv []*Entity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range c.hashmap {
+		entity := entry.entity
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, entity)
+	}
+	return
+}
+
+// SetWithoutTTL 覆盖已有Key时沿用原有代价, 新Key按 DefaultCost 计
+func (c *GDSCache) SetWithoutTTL(key string, value Value) {
+	c.SetWithoutTTLCost(key, value, 0)
+}
+
+func (c *GDSCache) SetWithTTL(key string, value Value, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *GDSCache) SetWithPTTL(key string, value Value, pttl int64) {
+	c.SetWithPTTLCost(key, value, pttl, 0)
+}
+
+// SetWithoutTTLCost 写入Key并指定重新计算代价, cost 小于等于0时与 SetWithoutTTL 相同
+func (c *GDSCache) SetWithoutTTLCost(key string, value Value, cost int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0, cost) {
+		c.wheel.Remove(key)
+	}
+}
+
+// SetWithPTTLCost 写入Key并指定毫秒级过期时间与重新计算代价, cost 小于等于0时与 SetWithPTTL 相同
+func (c *GDSCache) SetWithPTTLCost(key string, value Value, pttl int64, cost int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0, cost) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *GDSCache) SetWithSlidingPTTL(key string, value Value, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *GDSCache) set(key string, value Value, expireTime, sliding int64, cost int64) bool {
+	kvSize := int64(len(key)) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	if entry, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值, 视为再次访问
+		delta := int64(value.Len()) - int64(entry.entity.Value.Len())
+		c.seq++
+		entry.seq = c.seq
+		entry.priority = c.inflation + 1<<62
+		heap.Fix(&c.queue, entry.index) // 淘汰其他Key腾出空间时跳过自身
+		for c.overflow(delta, 0) && c.queue.Len() > 1 {
+			c.evict()
+		}
+		c.length += delta
+		old := entry.entity.Value
+		entry.entity.Value = value
+		entry.entity.ExpiredTime, entry.entity.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		if cost > 0 {
+			entry.cost = cost
+		}
+		c.touch(entry)
+		return true
+	}
+	// 新增缓存Key
+	for c.overflow(kvSize, 1) && c.queue.Len() > 0 {
+		c.evict()
+	}
+	if cost <= 0 {
+		cost = DefaultCost
+	}
+	entry := &gdsEntry{entity: &Entity{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding}, cost: cost}
+	c.hashmap[key] = entry
+	c.length += kvSize
+	c.seq++
+	entry.seq = c.seq
+	entry.priority = c.inflation + c.density(entry)
+	heap.Push(&c.queue, entry)
+	return true
+}
+
+// density 单位字节的重新计算代价
+func (c *GDSCache) density(entry *gdsEntry) float64 {
+	size := int64(len(entry.entity.Key)) + int64(entry.entity.Value.Len()) + c.overhead
+	if size < 1 {
+		size = 1
+	}
+	return float64(entry.cost) / float64(size)
+}
+
+// touch 按当前膨胀值重新计算优先级
+func (c *GDSCache) touch(entry *gdsEntry) {
+	c.seq++
+	entry.seq = c.seq
+	entry.priority = c.inflation + c.density(entry)
+	heap.Fix(&c.queue, entry.index)
+}
+
+// evict 淘汰优先级最低的Key, 并将膨胀值提升为其优先级
+func (c *GDSCache) evict() {
+	if c.queue.Len() == 0 {
+		return
+	}
+	entry := c.queue[0]
+	c.inflation = entry.priority
+	c.removeEntry(entry, EvictCapacity)
+}
+
+func (c *GDSCache) removeEntry(entry *gdsEntry, reason EvictReason) {
+	k, v := entry.entity.Key, entry.entity.Value
+	heap.Remove(&c.queue, entry.index)                      // 移除缓存
+	delete(c.hashmap, k)                                    // 移除映射
+	c.wheel.Remove(k)                                       // 移除定时器
+	c.length -= int64(len(k)) + int64(v.Len()) + c.overhead // 更新占用内存情况
+	// 移除后的善后处理
+	if c.callback != nil {
+		c.callback(k, v, reason)
+	}
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *GDSCache) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+
+// RemoveExpiredKey 移除过期Key, 不影响膨胀值
+func (c *GDSCache) RemoveExpiredKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在
+func (c *GDSCache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *GDSCache) MultiDeleteKey(keys []string, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *GDSCache) removeKey(key string, reason EvictReason) bool {
+	if entry, ok := c.hashmap[key]; ok {
+		c.removeEntry(entry, reason)
+		return true
+	}
+	return false
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *GDSCache) expireKey(key string) {
+	if entry, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, entry.entity) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+// Remove 淘汰优先级最低的缓存
+func (c *GDSCache) Remove() {
+	c.evict()
+}
+
+func (c *GDSCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.queue.Len()
+}
+
+// overflow 再写入 size 字节、n 个Key后是否超出容量上限
+func (c *GDSCache) overflow(size int64, n int) bool {
+	return (c.capacity > 0 && c.length+size > c.capacity) ||
+		(c.maxEntries > 0 && c.queue.Len()+n > c.maxEntries)
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *GDSCache) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	for c.overflow(0, 0) {
+		c.evict()
+	}
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰。
+// 已有Key的优先级在下次访问时按新的大小计算。
+func (c *GDSCache) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.length += (overhead - c.overhead) * int64(c.queue.Len())
+	c.overhead = overhead
+	for c.overflow(0, 0) {
+		c.evict()
+	}
+}
+
+// EstimateOverhead 估算每个Key在 gdsEntry、Entity、堆与 map 中的额外开销, 堆中的指针代替了链表节点
+func (c *GDSCache) EstimateOverhead() int64 {
+	return generic.EntryOverhead[string, Value](unsafe.Sizeof(gdsEntry{}), unsafe.Sizeof(uintptr(0))) -
+		int64(unsafe.Sizeof(list.Element{}))
+}
+
+func (c *GDSCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Bytes: c.length, Entries: c.queue.Len(), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+
+func (c *GDSCache) TTL(key string) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *GDSCache) PTTL(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.hashmap[key]; ok {
+		return remainingPTTL(entry.entity.ExpiredTime)
+	}
+	return -2
+}
+
+func (c *GDSCache) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *GDSCache) Stop() {
+	c.Close()
+}
+
+var (
+	_ CacheMemory = (*GDSCache)(nil)
+	_ CostSetter  = (*GDSCache)(nil)
+)
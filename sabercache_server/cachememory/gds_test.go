@@ -0,0 +1,97 @@
+package cachememory
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestGDSGet(t *testing.T) {
+	var cache CacheMemory = NewGDSCache(int64(1024), nil)
+	defer cache.Stop()
+	t.Run("SetWithoutTTL", func(t *testing.T) {
+		cache.SetWithoutTTL("key1", String("1234"))
+		if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+			t.Fatalf("cache hit key1=1234 failed")
+		}
+	})
+	t.Run("SetWithTTL", func(t *testing.T) {
+		cache.SetWithTTL("key1", String("1234"), 10)
+		if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+			t.Fatalf("cache hit key1=1234 failed")
+		}
+		if ttl := cache.TTL("key1"); ttl != 10 {
+			t.Fatalf("unexpected ttl %d", ttl)
+		}
+	})
+	t.Run("GetNilKey", func(t *testing.T) {
+		if _, ok := cache.Get("key2"); ok {
+			t.Fatalf("cache miss key2 failed")
+		}
+	})
+}
+
+// TestGDSEvictCost 优先淘汰代价低、体积大的Key
+func TestGDSEvictCost(t *testing.T) {
+	var keys []string
+	callback := func(key string, value Value, reason EvictReason) {
+		if reason == EvictCapacity {
+			keys = append(keys, key)
+		}
+	}
+	cache := NewGDSCache(int64(100), callback)
+	defer cache.Stop()
+	cache.SetWithoutTTLCost("cheap", String(strings.Repeat("v", 25)), 10)
+	cache.SetWithoutTTLCost("large", String(strings.Repeat("v", 45)), 100)
+	cache.SetWithoutTTLCost("small", String("v"), 10)
+	// cheap 10/30, large 100/50, small 10/6
+	cache.SetWithoutTTLCost("new", String(strings.Repeat("v", 30)), 10)
+	if len(keys) != 1 || keys[0] != "cheap" {
+		t.Fatalf("unexpected evicted keys %v", keys)
+	}
+	// 未指定代价时覆盖写入沿用原有代价
+	cache.SetWithoutTTL("large", String(strings.Repeat("v", 45)))
+	cache.SetWithoutTTL("other", String(strings.Repeat("v", 10)))
+	if len(keys) != 2 || keys[1] != "new" {
+		t.Fatalf("unexpected evicted keys %v", keys)
+	}
+	if stats := cache.Stats(); stats.Bytes > 100 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+// TestGDSAging 长期未访问的高代价Key随膨胀值增长最终被淘汰
+func TestGDSAging(t *testing.T) {
+	var keys []string
+	callback := func(key string, value Value, reason EvictReason) {
+		keys = append(keys, key)
+	}
+	cache := NewGDSCache(int64(40), callback)
+	defer cache.Stop()
+	cache.SetWithoutTTLCost("old", String("123456"), 50)
+	for i := 0; i < 100; i++ {
+		key := "key" + strconv.Itoa(i)
+		cache.SetWithoutTTLCost(key, String("123456"), 20)
+		cache.Get(key)
+	}
+	for _, k := range keys {
+		if k == "old" {
+			return
+		}
+	}
+	t.Fatalf("old key never evicted")
+}
+
+func TestShardedCost(t *testing.T) {
+	cache := NewShardedCache(4, 0, func(maxBytes int64) CacheMemory {
+		return NewGDSCache(maxBytes, nil)
+	})
+	defer cache.Stop()
+	cache.SetWithPTTLCost("key1", String("1234"), 10000, 100)
+	if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+		t.Fatalf("cache hit key1=1234 failed")
+	}
+	if pttl := cache.PTTL("key1"); pttl <= 0 || pttl > 10000 {
+		t.Fatalf("unexpected pttl %d", pttl)
+	}
+}
@@ -0,0 +1,121 @@
+// Package generic 提供基于泛型的类型安全缓存, 可作为库直接嵌入其他Go服务。
+// Key 为任意可比较类型, Value 只需实现 Sizer, 取出时无需类型断言。
+package generic
+
+import (
+	"time"
+	"unsafe"
+)
+
+type CacheMemory[K comparable, V Sizer] interface {
+	Get(key K) (V, bool)
+	GetAll() []*Entity[K, V]
+	SetWithoutTTL(key K, value V)
+	SetWithTTL(key K, value V, ttl int64)
+	SetWithPTTL(key K, value V, pttl int64)
+	SetWithSlidingPTTL(key K, value V, pttl int64)
+	ExpireKeyMonitor()
+	MultiDeleteKey(keys []K, t int64)
+	RemoveExpiredKey(key K)
+	Delete(key K) bool
+	Remove()
+	TTL(key K) int64
+	PTTL(key K) int64
+	Len() int
+	SetLimit(maxBytes int64, maxEntries int)
+	SetOverhead(overhead int64)
+	EstimateOverhead() int64
+	Stats() Stats
+	Stop()
+}
+
+type Entity[K comparable, V Sizer] struct {
+	Key         K
+	Value       V
+	ExpiredTime int64 // 到期时间(Unix毫秒), -1 表示永不过期
+	Sliding     int64 // 滑动过期的空闲时长(毫秒), 每次命中将到期时间顺延至此后, 0 表示按写入时间过期
+}
+
+// Stats 缓存占用与容量上限, 上限为0表示不限制
+type Stats struct {
+	Bytes      int64 // 当前占用字节数, 包含每个Key的额外开销
+	Entries    int   // 当前Key数量
+	MaxBytes   int64
+	MaxEntries int
+	Overhead   int64 // 每个Key额外计入的字节数
+}
+
+// Sizer Value 占用的字节数, 用于计算缓存容量
+type Sizer interface {
+	Len() int
+}
+
+// EvictReason Key被移出缓存的原因
+type EvictReason int
+
+const (
+	EvictCapacity EvictReason = iota // 超出容量上限被淘汰
+	EvictExpired                     // 过期
+	EvictDeleted                     // 调用 Delete 删除
+	EvictReplaced                    // 被新写入的 Value 覆盖, 回调收到的是旧 Value
+)
+
+func (r EvictReason) String() string {
+	switch r {
+	case EvictCapacity:
+		return "capacity"
+	case EvictExpired:
+		return "expired"
+	case EvictDeleted:
+		return "deleted"
+	case EvictReplaced:
+		return "replaced"
+	}
+	return "unknown"
+}
+
+// OnEliminated Key被移出缓存或 Value 被覆盖时的回调, 在持有缓存锁时调用, 不能再访问同一缓存
+type OnEliminated[K comparable, V Sizer] func(key K, value V, reason EvictReason)
+
+// keySize Key占用的字节数, string 按长度计算, 其余类型按自身大小计算
+func keySize[K comparable](key K) int64 {
+	if s, ok := any(key).(string); ok {
+		return int64(len(s))
+	}
+	return int64(unsafe.Sizeof(key))
+}
+
+// remainingPTTL 根据到期时间(毫秒时间戳)计算剩余毫秒数, -1 表示永不过期, -2 表示已过期
+func remainingPTTL(expiredTime int64) int64 {
+	if expiredTime == -1 {
+		return -1
+	}
+	if pttl := expiredTime - time.Now().UnixMilli(); pttl > 0 {
+		return pttl
+	}
+	return -2
+}
+
+// slideExpire 滑动过期的Key被访问时顺延到期时间, 需持有写锁
+func slideExpire[K comparable, V Sizer](e *Entity[K, V]) {
+	if e.Sliding > 0 {
+		e.ExpiredTime = time.Now().UnixMilli() + e.Sliding
+	}
+}
+
+// rearmExpire 时间轮到期时, 滑动过期的Key若期间被访问过则按顺延后的到期时间重新计时, 返回是否重新计时
+func rearmExpire[K comparable, V Sizer](wheel *TimingWheel[K], e *Entity[K, V]) bool {
+	if e.Sliding > 0 && e.ExpiredTime > time.Now().UnixMilli() {
+		wheel.Add(e.Key, time.UnixMilli(e.ExpiredTime))
+		return true
+	}
+	return false
+}
+
+// pttlToTTL 将剩余毫秒数向上取整为秒
+func pttlToTTL(pttl int64) int64 {
+	if pttl < 0 {
+		return pttl
+	}
+	return (pttl + 999) / 1000
+}
@@ -0,0 +1,81 @@
+package generic
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+type String string
+
+func (d String) Len() int {
+	return len(d)
+}
+
+type user struct {
+	id   int64
+	name string
+}
+
+func (u *user) Len() int {
+	return int(unsafe.Sizeof(*u)) + len(u.name)
+}
+
+func newAllCaches[K comparable, V Sizer](maxBytes int64, callback OnEliminated[K, V]) map[string]CacheMemory[K, V] {
+	return map[string]CacheMemory[K, V]{
+		"lru":    NewLRUCache(maxBytes, callback),
+		"lfu":    NewLFUCache(maxBytes, callback),
+		"fifo":   NewFIFOCache(maxBytes, callback),
+		"clock":  NewClockCache(maxBytes, callback),
+		"s3fifo": NewS3FIFOCache(maxBytes, callback),
+	}
+}
+
+// TestTypedCache 非 string 的Key与指针类型的Value, 取出时无需类型断言
+func TestTypedCache(t *testing.T) {
+	u1 := &user{id: 1, name: "saber"}
+	u2 := &user{id: 2, name: "archer"}
+	for name, cache := range newAllCaches[int64, *user](int64(1024), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			cache.SetWithoutTTL(u1.id, u1)
+			cache.SetWithTTL(u2.id, u2, 10)
+			if u, ok := cache.Get(1); !ok || u.name != "saber" {
+				t.Fatalf("cache hit 1=saber failed")
+			}
+			if u, ok := cache.Get(3); ok || u != nil {
+				t.Fatalf("cache miss 3 failed")
+			}
+			if ttl := cache.TTL(2); ttl != 10 {
+				t.Fatalf("ttl test 2 failed, got %d", ttl)
+			}
+			if kv := cache.GetAll(); len(kv) != 2 {
+				t.Fatalf("getall failed, got %d", len(kv))
+			}
+		})
+	}
+}
+
+// TestTypedCacheEvict 容量按 Key 自身大小与 Value.Len() 计算
+func TestTypedCacheEvict(t *testing.T) {
+	keys := make([]int32, 0)
+	callback := func(key int32, value String, reason EvictReason) {
+		keys = append(keys, key)
+	}
+	for name, cache := range newAllCaches[int32, String](int64(18), callback) {
+		keys = keys[:0]
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			cache.SetWithoutTTL(1, String("v1"))
+			cache.SetWithoutTTL(2, String("v2"))
+			cache.SetWithoutTTL(3, String("v3"))
+			cache.SetWithoutTTL(4, String("v4"))
+			if l := cache.Len(); l != 3 {
+				t.Fatalf("expect len 3, got %d", l)
+			}
+			if expect := []int32{1}; !reflect.DeepEqual(expect, keys) {
+				t.Fatalf("expect evicted %v, got %v", expect, keys)
+			}
+		})
+	}
+}
@@ -0,0 +1,305 @@
+package generic
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// ClockCache CLOCK(second-chance)淘汰策略
+// 命中时只在读锁下置位访问标记, 不移动链表节点; 淘汰时从链尾开始检查,
+// 访问标记被置位的Key清除标记后移回链头获得第二次机会, 否则淘汰。
+type ClockCache[K comparable, V Sizer] struct {
+	capacity         int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries       int   // Key 数量上限, 0 表示不限制
+	length           int64 // Cache 当前容量(Byte)
+	overhead         int64 // 每个Key额外计入的字节数
+	hashmap          map[K]*list.Element
+	wheel            *TimingWheel[K]
+	doublyLinkedList *list.List // 链头表示最近写入或获得第二次机会
+	mu               sync.RWMutex
+	stop             chan struct{}
+	stopOnce         sync.Once
+	callback         OnEliminated[K, V]
+}
+
+type clockEntry[K comparable, V Sizer] struct {
+	Entity[K, V]
+	visited atomic.Bool
+}
+
+func NewClockCache[K comparable, V Sizer](maxBytes int64, callback OnEliminated[K, V]) *ClockCache[K, V] {
+	c := &ClockCache[K, V]{
+		capacity:         maxBytes,
+		hashmap:          make(map[K]*list.Element),
+		wheel:            NewTimingWheel[K](DefaultTick, SystemClock),
+		doublyLinkedList: list.New(),
+		callback:         callback,
+		stop:             make(chan struct{}),
+	}
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+// Get 从缓存获取对应Key的Value。
+// ok 指明查询结果 false代表查无此Key
+func (c *ClockCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.RLock()
+	if elem, ok := c.hashmap[key]; ok {
+		entry := elem.Value.(*clockEntry[K, V])
+		if entry.ExpiredTime != -1 && entry.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.RUnlock()
+			c.RemoveExpiredKey(key)
+			return value, false
+		}
+		if !entry.visited.Load() {
+			entry.visited.Store(true)
+		}
+		value = entry.Value
+		sliding := entry.Sliding > 0
+		c.mu.RUnlock()
+		if sliding {
+			c.slide(key)
+		}
+		return value, true
+	}
+	c.mu.RUnlock()
+	return
+}
+
+// slide 顺延滑动过期Key的到期时间, Get 只持有读锁, 需另外加写锁
+func (c *ClockCache[K, V]) slide(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		slideExpire(&elem.Value.(*clockEntry[K, V]).Entity)
+	}
+}
+
+func (c *ClockCache[K, V]) GetAll() (kv []*Entity[K, V]) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, elem := range c.hashmap {
+		entry := elem.Value.(*clockEntry[K, V])
+		if entry.ExpiredTime != -1 && entry.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, &entry.Entity)
+	}
+	return
+}
+
+func (c *ClockCache[K, V]) SetWithoutTTL(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0) {
+		c.wheel.Remove(key)
+	}
+}
+
+func (c *ClockCache[K, V]) SetWithTTL(key K, value V, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *ClockCache[K, V]) SetWithPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *ClockCache[K, V]) SetWithSlidingPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *ClockCache[K, V]) set(key K, value V, expireTime, sliding int64) bool {
+	kvSize := keySize(key) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	if elem, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值, 视为一次访问
+		entry := elem.Value.(*clockEntry[K, V])
+		delta := int64(value.Len()) - int64(entry.Value.Len())
+		for c.overflow(delta, 0) && c.evict(elem) {
+		}
+		c.length += delta
+		old := entry.Value
+		entry.Value = value
+		entry.ExpiredTime, entry.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		entry.visited.Store(true)
+		return true
+	}
+	// 新增缓存Key
+	for c.overflow(kvSize, 1) && c.evict(nil) {
+	}
+	entry := &clockEntry[K, V]{Entity: Entity[K, V]{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding}}
+	c.hashmap[key] = c.doublyLinkedList.PushFront(entry)
+	c.length += kvSize
+	return true
+}
+
+// evict 淘汰除 skip 以外的一枚缓存, 返回是否有缓存被淘汰
+func (c *ClockCache[K, V]) evict(skip *list.Element) bool {
+	// 每个Key最多获得一次第二次机会, 循环次数有上限
+	for i := c.doublyLinkedList.Len() * 2; i > 0; i-- {
+		elem := c.doublyLinkedList.Back()
+		if elem == skip {
+			c.doublyLinkedList.MoveToFront(elem)
+			continue
+		}
+		entry := elem.Value.(*clockEntry[K, V])
+		if entry.visited.Load() {
+			entry.visited.Store(false)
+			c.doublyLinkedList.MoveToFront(elem)
+			continue
+		}
+		c.removeElement(elem, EvictCapacity)
+		return true
+	}
+	return false
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *ClockCache[K, V]) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+
+func (c *ClockCache[K, V]) RemoveExpiredKey(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在
+func (c *ClockCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *ClockCache[K, V]) MultiDeleteKey(keys []K, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *ClockCache[K, V]) removeKey(key K, reason EvictReason) bool {
+	if elem, ok := c.hashmap[key]; ok {
+		c.removeElement(elem, reason)
+		return true
+	}
+	return false
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *ClockCache[K, V]) expireKey(key K) {
+	if elem, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, &elem.Value.(*clockEntry[K, V]).Entity) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+func (c *ClockCache[K, V]) removeElement(elem *list.Element, reason EvictReason) {
+	entry := elem.Value.(*clockEntry[K, V])
+	k, v := entry.Key, entry.Value
+	delete(c.hashmap, k)                                 // 移除映射
+	c.doublyLinkedList.Remove(elem)                      // 移除缓存
+	c.wheel.Remove(k)                                    // 移除定时器
+	c.length -= keySize(k) + int64(v.Len()) + c.overhead // 更新占用内存情况
+	// 移除后的善后处理
+	if c.callback != nil {
+		c.callback(k, v, reason)
+	}
+}
+
+// Remove 按CLOCK策略淘汰一枚缓存
+func (c *ClockCache[K, V]) Remove() {
+	c.evict(nil)
+}
+
+func (c *ClockCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.doublyLinkedList.Len()
+}
+
+// overflow 再写入 size 字节、n 个Key后是否超出容量上限
+func (c *ClockCache[K, V]) overflow(size int64, n int) bool {
+	return (c.capacity > 0 && c.length+size > c.capacity) ||
+		(c.maxEntries > 0 && c.doublyLinkedList.Len()+n > c.maxEntries)
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *ClockCache[K, V]) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	for c.overflow(0, 0) && c.evict(nil) {
+	}
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰
+func (c *ClockCache[K, V]) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.length += (overhead - c.overhead) * int64(c.doublyLinkedList.Len())
+	c.overhead = overhead
+	for c.overflow(0, 0) && c.evict(nil) {
+	}
+}
+
+// EstimateOverhead 估算每个Key在链表节点、clockEntry 与 map 中的额外开销
+func (c *ClockCache[K, V]) EstimateOverhead() int64 {
+	return EntryOverhead[K, V]() - entityOverhead[K, V]() + sizeClass(unsafe.Sizeof(clockEntry[K, V]{}))
+}
+
+func (c *ClockCache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Stats{Bytes: c.length, Entries: c.doublyLinkedList.Len(), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+
+func (c *ClockCache[K, V]) TTL(key K) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *ClockCache[K, V]) PTTL(key K) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if elem, ok := c.hashmap[key]; ok {
+		return remainingPTTL(elem.Value.(*clockEntry[K, V]).ExpiredTime)
+	}
+	return -2
+}
+
+func (c *ClockCache[K, V]) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *ClockCache[K, V]) Stop() {
+	c.Close()
+}
+
+var _ CacheMemory[string, Sizer] = (*ClockCache[string, Sizer])(nil)
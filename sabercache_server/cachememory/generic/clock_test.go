@@ -0,0 +1,27 @@
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestClockSecondChance 被访问过的Key获得第二次机会
+func TestClockSecondChance(t *testing.T) {
+	var keys []string
+	cache := NewClockCache[string, String](int64(12), func(key string, value String, reason EvictReason) {
+		keys = append(keys, key)
+	})
+	defer cache.Stop()
+	cache.SetWithoutTTL("k1", String("v1"))
+	cache.SetWithoutTTL("k2", String("v2"))
+	cache.SetWithoutTTL("k3", String("v3"))
+	cache.Get("k1")
+	cache.SetWithoutTTL("k4", String("v4"))
+	cache.SetWithoutTTL("k5", String("v5"))
+	if expect := []string{"k2", "k3"}; !reflect.DeepEqual(expect, keys) {
+		t.Fatalf("expect evicted %v, got %v", expect, keys)
+	}
+	if _, ok := cache.Get("k1"); !ok {
+		t.Fatalf("k1 should be kept")
+	}
+}
@@ -0,0 +1,87 @@
+package generic
+
+import (
+	"container/list"
+	"sync"
+)
+
+// GhostList 记录最近因容量不足被淘汰的Key(只保存Key与大小, 不保存Value)。
+// 未命中时若Key仍在列表中, 说明容量更大时本可以命中, 据此估算增加内存带来的收益。
+// 列表按写入顺序最多保留 maxEntries 个Key。
+type GhostList[K comparable] struct {
+	maxEntries int
+	ghosts     *list.List // 链头表示最近淘汰
+	hashmap    map[K]*list.Element
+	hits       int64
+	hitBytes   int64
+	mu         sync.Mutex
+}
+
+type ghostEntry[K comparable] struct {
+	key  K
+	size int64
+}
+
+// GhostStats hits/hitBytes 为增加内存后本可命中的次数与字节数
+type GhostStats struct {
+	Entries    int
+	MaxEntries int
+	Hits       int64
+	HitBytes   int64
+}
+
+func NewGhostList[K comparable](maxEntries int) *GhostList[K] {
+	return &GhostList[K]{
+		maxEntries: maxEntries,
+		ghosts:     list.New(),
+		hashmap:    make(map[K]*list.Element),
+	}
+}
+
+// Add 记录被淘汰的Key, size 为其占用的字节数
+func (g *GhostList[K]) Add(key K, size int64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if elem, ok := g.hashmap[key]; ok {
+		elem.Value.(*ghostEntry[K]).size = size
+		g.ghosts.MoveToFront(elem)
+		return
+	}
+	g.hashmap[key] = g.ghosts.PushFront(&ghostEntry[K]{key: key, size: size})
+	for g.ghosts.Len() > g.maxEntries {
+		g.remove(g.ghosts.Back())
+	}
+}
+
+// Hit 缓存未命中时调用, Key在列表中时计为一次本可命中并将其移出列表
+func (g *GhostList[K]) Hit(key K) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	elem, ok := g.hashmap[key]
+	if !ok {
+		return false
+	}
+	g.hits++
+	g.hitBytes += elem.Value.(*ghostEntry[K]).size
+	g.remove(elem)
+	return true
+}
+
+// Remove Key重新写入缓存或被删除时移出列表
+func (g *GhostList[K]) Remove(key K) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if elem, ok := g.hashmap[key]; ok {
+		g.remove(elem)
+	}
+}
+
+func (g *GhostList[K]) remove(elem *list.Element) {
+	delete(g.hashmap, g.ghosts.Remove(elem).(*ghostEntry[K]).key)
+}
+
+func (g *GhostList[K]) Stats() GhostStats {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return GhostStats{Entries: g.ghosts.Len(), MaxEntries: g.maxEntries, Hits: g.hits, HitBytes: g.hitBytes}
+}
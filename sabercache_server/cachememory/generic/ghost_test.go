@@ -0,0 +1,23 @@
+package generic
+
+import "testing"
+
+func TestGhostList(t *testing.T) {
+	g := NewGhostList[string](2)
+	g.Add("k1", 10)
+	g.Add("k2", 20)
+	g.Add("k3", 30)
+	if g.Hit("k1") {
+		t.Fatalf("k1 should be trimmed")
+	}
+	if !g.Hit("k2") || g.Hit("k2") {
+		t.Fatalf("k2 should hit once")
+	}
+	g.Remove("k3")
+	if g.Hit("k3") {
+		t.Fatalf("k3 should be removed")
+	}
+	if stats := g.Stats(); stats != (GhostStats{Entries: 0, MaxEntries: 2, Hits: 1, HitBytes: 20}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
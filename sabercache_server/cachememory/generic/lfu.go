@@ -0,0 +1,357 @@
+package generic
+
+import (
+	"container/list"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// LFUCache 最不经常使用缓存
+// 访问次数相同的Key放在同一个频率桶中, 频率桶按访问次数升序串成链表,
+// 访问Key时将其移入下一个频率桶, 淘汰时取最低频率桶中最早进入的Key, 均为O(1)。
+// decay 大于0时每隔 decay 将全部访问次数减半, 使曾经的热点Key最终能够被淘汰。
+type LFUCache[K comparable, V Sizer] struct {
+	capacity   int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries int   // Key 数量上限, 0 表示不限制
+	length     int64
+	overhead   int64               // 每个Key额外计入的字节数
+	buckets    *list.List          // 频率桶, 链头访问次数最少
+	hashmap    map[K]*list.Element // Key 在所属频率桶中的元素
+	decay      time.Duration
+	lastDecay  time.Time
+	clock      Clock
+	wheel      *TimingWheel[K]
+	mu         sync.Mutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	callback   OnEliminated[K, V]
+}
+
+type lfuBucket struct {
+	freq  int
+	items *list.List // 链头为最近进入该桶的Key
+}
+
+type lfuEntry[K comparable, V Sizer] struct {
+	entity *Entity[K, V]
+	bucket *list.Element
+}
+
+// NewLFUCache 访问次数不衰减
+func NewLFUCache[K comparable, V Sizer](capacity int64, callback OnEliminated[K, V]) *LFUCache[K, V] {
+	return NewLFUCacheWithDecay(capacity, 0, callback)
+}
+
+// NewLFUCacheWithDecay decay 为访问次数减半的周期, 0 表示不衰减
+func NewLFUCacheWithDecay[K comparable, V Sizer](capacity int64, decay time.Duration, callback OnEliminated[K, V]) *LFUCache[K, V] {
+	c := &LFUCache[K, V]{
+		capacity: capacity,
+		buckets:  list.New(),
+		hashmap:  make(map[K]*list.Element),
+		decay:    decay,
+		clock:    SystemClock,
+		wheel:    NewTimingWheel[K](DefaultTick, SystemClock),
+		stop:     make(chan struct{}),
+		callback: callback,
+	}
+	c.lastDecay = c.clock.Now()
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+func (c *LFUCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	if elem, ok := c.hashmap[key]; ok {
+		entity := elem.Value.(*lfuEntry[K, V]).entity
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.Unlock()
+			c.RemoveExpiredKey(key)
+			return value, false
+		}
+		slideExpire(entity)
+		c.decayFreq()
+		c.increment(elem)
+		c.mu.Unlock()
+		return entity.Value, true
+	}
+	c.mu.Unlock()
+	return
+}
+func (c *LFUCache[K, V]) GetAll() (kv []*Entity[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, elem := range c.hashmap {
+		entity := elem.Value.(*lfuEntry[K, V]).entity
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, entity)
+	}
+	return
+}
+func (c *LFUCache[K, V]) SetWithoutTTL(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0) {
+		c.wheel.Remove(key)
+	}
+}
+func (c *LFUCache[K, V]) SetWithTTL(key K, value V, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *LFUCache[K, V]) SetWithPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *LFUCache[K, V]) SetWithSlidingPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *LFUCache[K, V]) set(key K, value V, expireTime, sliding int64) bool {
+	kvSize := keySize(key) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	c.decayFreq()
+	if elem, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值, 视为再次访问
+		entity := elem.Value.(*lfuEntry[K, V]).entity
+		c.increment(elem)
+		for c.overflow(int64(value.Len())-int64(entity.Value.Len()), 0) {
+			c.removeElement(c.victim(entity), EvictCapacity)
+		}
+		c.length += int64(value.Len()) - int64(entity.Value.Len())
+		old := entity.Value
+		entity.Value = value
+		entity.ExpiredTime, entity.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		return true
+	}
+	for c.overflow(kvSize, 1) {
+		c.Remove()
+	}
+	c.push(&Entity[K, V]{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding})
+	c.length += kvSize
+	return true
+}
+
+// push 新Key以访问次数1放入最低频率桶
+func (c *LFUCache[K, V]) push(entity *Entity[K, V]) {
+	front := c.buckets.Front()
+	if front == nil || front.Value.(*lfuBucket).freq != 1 {
+		front = c.buckets.PushFront(&lfuBucket{freq: 1, items: list.New()})
+	}
+	c.hashmap[entity.Key] = front.Value.(*lfuBucket).items.PushFront(&lfuEntry[K, V]{entity: entity, bucket: front})
+}
+
+// increment 访问次数加1, 将Key移入下一个频率桶
+func (c *LFUCache[K, V]) increment(elem *list.Element) {
+	entry := elem.Value.(*lfuEntry[K, V])
+	cur := entry.bucket
+	bucket := cur.Value.(*lfuBucket)
+	next := cur.Next()
+	if next == nil || next.Value.(*lfuBucket).freq != bucket.freq+1 {
+		next = c.buckets.InsertAfter(&lfuBucket{freq: bucket.freq + 1, items: list.New()}, cur)
+	}
+	bucket.items.Remove(elem)
+	if bucket.items.Len() == 0 {
+		c.buckets.Remove(cur)
+	}
+	entry.bucket = next
+	c.hashmap[entry.entity.Key] = next.Value.(*lfuBucket).items.PushFront(entry)
+}
+
+// decayFreq 每经过一个 decay 周期将全部访问次数减半(最少为1),
+// 减半后访问次数相同的桶合并, 原访问次数较高的Key排在桶的前面
+func (c *LFUCache[K, V]) decayFreq() {
+	if c.decay <= 0 {
+		return
+	}
+	periods := c.clock.Now().Sub(c.lastDecay) / c.decay
+	if periods <= 0 {
+		return
+	}
+	c.lastDecay = c.lastDecay.Add(periods * c.decay)
+	shift := uint(63)
+	if periods < 63 {
+		shift = uint(periods)
+	}
+	for elem := c.buckets.Front(); elem != nil; {
+		next := elem.Next()
+		bucket := elem.Value.(*lfuBucket)
+		bucket.freq >>= shift
+		if bucket.freq < 1 {
+			bucket.freq = 1
+		}
+		if prev := elem.Prev(); prev != nil && prev.Value.(*lfuBucket).freq == bucket.freq {
+			items := prev.Value.(*lfuBucket).items
+			for e := bucket.items.Back(); e != nil; e = bucket.items.Back() {
+				entry := bucket.items.Remove(e).(*lfuEntry[K, V])
+				entry.bucket = prev
+				c.hashmap[entry.entity.Key] = items.PushFront(entry)
+			}
+			c.buckets.Remove(elem)
+		}
+		elem = next
+	}
+}
+
+// victim 返回最低频率桶中最早进入的Key, 跳过 skip
+func (c *LFUCache[K, V]) victim(skip *Entity[K, V]) *list.Element {
+	for b := c.buckets.Front(); b != nil; b = b.Next() {
+		for elem := b.Value.(*lfuBucket).items.Back(); elem != nil; elem = elem.Prev() {
+			if elem.Value.(*lfuEntry[K, V]).entity != skip {
+				return elem
+			}
+		}
+	}
+	return nil
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *LFUCache[K, V]) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+func (c *LFUCache[K, V]) RemoveExpiredKey(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在
+func (c *LFUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *LFUCache[K, V]) MultiDeleteKey(keys []K, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *LFUCache[K, V]) Remove() {
+	if elem := c.victim(nil); elem != nil {
+		c.removeElement(elem, EvictCapacity)
+	}
+}
+
+func (c *LFUCache[K, V]) removeKey(key K, reason EvictReason) bool {
+	if elem, ok := c.hashmap[key]; ok {
+		c.removeElement(elem, reason)
+		return true
+	}
+	return false
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *LFUCache[K, V]) expireKey(key K) {
+	if elem, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, elem.Value.(*lfuEntry[K, V]).entity) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+func (c *LFUCache[K, V]) removeElement(elem *list.Element, reason EvictReason) {
+	entry := elem.Value.(*lfuEntry[K, V])
+	bucket := entry.bucket.Value.(*lfuBucket)
+	bucket.items.Remove(elem)
+	if bucket.items.Len() == 0 {
+		c.buckets.Remove(entry.bucket)
+	}
+	key, value := entry.entity.Key, entry.entity.Value
+	delete(c.hashmap, key)
+	c.wheel.Remove(key)
+	c.length = c.length - keySize(key) - int64(value.Len()) - c.overhead
+
+	if c.callback != nil {
+		c.callback(key, value, reason)
+	}
+}
+func (c *LFUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.hashmap)
+}
+
+// overflow 再写入 size 字节、n 个Key后是否超出容量上限
+func (c *LFUCache[K, V]) overflow(size int64, n int) bool {
+	return (c.capacity > 0 && c.length+size > c.capacity) ||
+		(c.maxEntries > 0 && len(c.hashmap)+n > c.maxEntries)
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *LFUCache[K, V]) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	for c.overflow(0, 0) {
+		c.Remove()
+	}
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰
+func (c *LFUCache[K, V]) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.length += (overhead - c.overhead) * int64(len(c.hashmap))
+	c.overhead = overhead
+	for c.overflow(0, 0) {
+		c.Remove()
+	}
+}
+
+// EstimateOverhead 估算每个Key在链表节点、Entity 与 map 中的额外开销
+func (c *LFUCache[K, V]) EstimateOverhead() int64 {
+	return EntryOverhead[K, V](unsafe.Sizeof(lfuEntry[K, V]{}))
+}
+
+func (c *LFUCache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Bytes: c.length, Entries: len(c.hashmap), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+func (c *LFUCache[K, V]) TTL(key K) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *LFUCache[K, V]) PTTL(key K) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		return remainingPTTL(elem.Value.(*lfuEntry[K, V]).entity.ExpiredTime)
+	}
+	return -2
+}
+func (c *LFUCache[K, V]) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+func (c *LFUCache[K, V]) Stop() {
+	c.Close()
+}
+
+var _ CacheMemory[string, Sizer] = (*LFUCache[string, Sizer])(nil)
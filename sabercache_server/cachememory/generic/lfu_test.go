@@ -0,0 +1,66 @@
+package generic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLFUDecay(t *testing.T) {
+	clock := newFakeClock()
+	newCache := func(decay time.Duration) *LFUCache[string, String] {
+		cache := NewLFUCacheWithDecay[string, String](int64(8), decay, nil)
+		cache.clock = clock
+		cache.lastDecay = clock.Now()
+		return cache
+	}
+	t.Run("热点Key衰减后被淘汰", func(t *testing.T) {
+		cache := newCache(time.Minute)
+		defer cache.Stop()
+		cache.SetWithoutTTL("k1", String("v1"))
+		for i := 0; i < 7; i++ {
+			cache.Get("k1")
+		}
+		clock.Add(3 * time.Minute)
+		cache.SetWithoutTTL("k2", String("v2"))
+		cache.SetWithoutTTL("k3", String("v3"))
+		if _, ok := cache.Get("k1"); ok {
+			t.Fatalf("decayed key k1 not evicted")
+		}
+		if _, ok := cache.Get("k2"); !ok {
+			t.Fatalf("Get k2 fialed")
+		}
+	})
+	t.Run("不衰减", func(t *testing.T) {
+		cache := newCache(0)
+		defer cache.Stop()
+		cache.SetWithoutTTL("k1", String("v1"))
+		for i := 0; i < 7; i++ {
+			cache.Get("k1")
+		}
+		clock.Add(3 * time.Minute)
+		cache.SetWithoutTTL("k2", String("v2"))
+		cache.SetWithoutTTL("k3", String("v3"))
+		if _, ok := cache.Get("k1"); !ok {
+			t.Fatalf("Get k1 fialed")
+		}
+	})
+	t.Run("减半后保留相对顺序", func(t *testing.T) {
+		cache := newCache(time.Minute)
+		defer cache.Stop()
+		cache.SetWithoutTTL("k1", String("v1"))
+		cache.SetWithoutTTL("k2", String("v2"))
+		for i := 0; i < 3; i++ {
+			cache.Get("k1")
+		}
+		cache.Get("k2")
+		// k1:4 k2:2, 减半后 k1:2 k2:1
+		clock.Add(time.Minute)
+		cache.SetWithoutTTL("k3", String("v3"))
+		if _, ok := cache.Get("k2"); ok {
+			t.Fatalf("Remove k2 fialed")
+		}
+		if _, ok := cache.Get("k1"); !ok {
+			t.Fatalf("Get k1 fialed")
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package generic
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+type LRUCache[K comparable, V Sizer] struct {
+	capacity         int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries       int   // Key 数量上限, 0 表示不限制
+	length           int64 // Cache 当前容量(Byte)
+	overhead         int64 // 每个Key额外计入的字节数
+	hashmap          map[K]*list.Element
+	wheel            *TimingWheel[K]
+	doublyLinkedList *list.List // 链头表示最近使用
+	mu               sync.Mutex
+	stop             chan struct{}
+	stopOnce         sync.Once
+	callback         OnEliminated[K, V]
+}
+
+func NewLRUCache[K comparable, V Sizer](maxBytes int64, callback OnEliminated[K, V]) *LRUCache[K, V] {
+	c := &LRUCache[K, V]{
+		capacity:         maxBytes,
+		hashmap:          make(map[K]*list.Element),
+		wheel:            NewTimingWheel[K](DefaultTick, SystemClock),
+		doublyLinkedList: list.New(),
+		callback:         callback,
+		stop:             make(chan struct{}),
+	}
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+// Get 从缓存获取对应Key的Value。
+// ok 指明查询结果 false代表查无此Key
+func (c *LRUCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.Lock()
+	if elem, ok := c.hashmap[key]; ok {
+		entity := elem.Value.(*Entity[K, V])
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.Unlock()
+			c.RemoveExpiredKey(key)
+			return value, false
+		}
+		slideExpire(entity)
+		c.doublyLinkedList.MoveToFront(elem)
+		c.mu.Unlock()
+		return entity.Value, true
+	}
+	c.mu.Unlock()
+	return
+}
+func (c *LRUCache[K, V]) GetAll() (kv []*Entity[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, elem := range c.hashmap {
+		entity := elem.Value.(*Entity[K, V])
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, entity)
+	}
+	return
+}
+func (c *LRUCache[K, V]) SetWithoutTTL(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0) {
+		c.wheel.Remove(key)
+	}
+}
+
+func (c *LRUCache[K, V]) SetWithTTL(key K, value V, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *LRUCache[K, V]) SetWithPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *LRUCache[K, V]) SetWithSlidingPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *LRUCache[K, V]) set(key K, value V, expireTime, sliding int64) bool {
+	kvSize := keySize(key) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	if elem, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值
+		c.doublyLinkedList.MoveToFront(elem)
+		oldEntry := elem.Value.(*Entity[K, V])
+		for c.overflow(int64(value.Len())-int64(oldEntry.Value.Len()), 0) {
+			c.Remove()
+		}
+		// 先更新写入字节 再更新
+		c.length += int64(value.Len()) - int64(oldEntry.Value.Len())
+		old := oldEntry.Value
+		oldEntry.Value = value
+		oldEntry.ExpiredTime, oldEntry.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		return true
+	}
+	// 新增缓存Key
+	for c.overflow(kvSize, 1) {
+		c.Remove()
+	}
+	elem := c.doublyLinkedList.PushFront(&Entity[K, V]{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding})
+	c.hashmap[key] = elem
+	c.length += kvSize
+	return true
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *LRUCache[K, V]) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+func (c *LRUCache[K, V]) RemoveExpiredKey(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在
+func (c *LRUCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *LRUCache[K, V]) MultiDeleteKey(keys []K, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *LRUCache[K, V]) removeKey(key K, reason EvictReason) bool {
+	if elem, ok := c.hashmap[key]; ok {
+		c.removeElement(elem, reason)
+		return true
+	}
+	return false
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *LRUCache[K, V]) expireKey(key K) {
+	if elem, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, elem.Value.(*Entity[K, V])) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+func (c *LRUCache[K, V]) removeElement(elem *list.Element, reason EvictReason) {
+	entry := elem.Value.(*Entity[K, V])
+	k, v := entry.Key, entry.Value
+	delete(c.hashmap, k)                                 // 移除映射
+	c.doublyLinkedList.Remove(elem)                      // 移除缓存
+	c.wheel.Remove(k)                                    // 移除定时器
+	c.length -= keySize(k) + int64(v.Len()) + c.overhead // 更新占用内存情况
+	// 移除后的善后处理
+	if c.callback != nil {
+		c.callback(k, v, reason)
+	}
+}
+
+// Remove 淘汰一枚最近最不常用缓存
+func (c *LRUCache[K, V]) Remove() {
+	if tailElem := c.doublyLinkedList.Back(); tailElem != nil {
+		c.removeElement(tailElem, EvictCapacity)
+	}
+}
+func (c *LRUCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.doublyLinkedList.Len()
+}
+
+// overflow 再写入 size 字节、n 个Key后是否超出容量上限
+func (c *LRUCache[K, V]) overflow(size int64, n int) bool {
+	return (c.capacity > 0 && c.length+size > c.capacity) ||
+		(c.maxEntries > 0 && c.doublyLinkedList.Len()+n > c.maxEntries)
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *LRUCache[K, V]) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	for c.overflow(0, 0) {
+		c.Remove()
+	}
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰
+func (c *LRUCache[K, V]) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.length += (overhead - c.overhead) * int64(c.doublyLinkedList.Len())
+	c.overhead = overhead
+	for c.overflow(0, 0) {
+		c.Remove()
+	}
+}
+
+// EstimateOverhead 估算每个Key在链表节点、Entity 与 map 中的额外开销
+func (c *LRUCache[K, V]) EstimateOverhead() int64 {
+	return EntryOverhead[K, V]()
+}
+
+func (c *LRUCache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Bytes: c.length, Entries: c.doublyLinkedList.Len(), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+func (c *LRUCache[K, V]) TTL(key K) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *LRUCache[K, V]) PTTL(key K) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		return remainingPTTL(elem.Value.(*Entity[K, V]).ExpiredTime)
+	}
+	return -2
+}
+func (c *LRUCache[K, V]) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+func (c *LRUCache[K, V]) Stop() {
+	c.Close()
+}
+
+var _ CacheMemory[string, Sizer] = (*LRUCache[string, Sizer])(nil)
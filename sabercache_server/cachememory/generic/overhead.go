@@ -0,0 +1,54 @@
+package generic
+
+import (
+	"container/list"
+	"unsafe"
+)
+
+// 默认只统计 len(key)+Value.Len(), 链表节点、Entity 以及 map 本身占用的内存并不计入,
+// 调用 SetOverhead 后每个Key额外计入固定字节数, 使容量更接近真实的堆内存占用。
+// EstimateOverhead 按各淘汰策略的内部结构估算该值, 未计入时间轮定时器与 Value 装箱的开销。
+
+// mapLoadFactor Go map 的平均装载因子 6.5/8
+const mapLoadFactor = 6.5 / 8
+
+// sizeClass 按Go内存分配器的小对象规格向上取整
+func sizeClass(n uintptr) int64 {
+	switch {
+	case n <= 8:
+		return 8
+	case n <= 16:
+		return 16
+	case n <= 24:
+		return 24
+	case n <= 32:
+		return 32
+	}
+	return int64((n + 15) / 16 * 16)
+}
+
+// mapEntryOverhead map[K]*list.Element 中每个Key的开销: 键值与 tophash 按装载因子折算
+func mapEntryOverhead[K comparable]() int64 {
+	var key K
+	return int64(float64(unsafe.Sizeof(key)+unsafe.Sizeof(uintptr(0))+1) / mapLoadFactor)
+}
+
+// elementOverhead 每个 list.Element 的开销
+func elementOverhead() int64 {
+	return sizeClass(unsafe.Sizeof(list.Element{}))
+}
+
+// entityOverhead 每个 Entity 的开销
+func entityOverhead[K comparable, V Sizer]() int64 {
+	return sizeClass(unsafe.Sizeof(Entity[K, V]{}))
+}
+
+// EntryOverhead 估算使用 map[K]*list.Element 索引、链表节点保存 Entity 的缓存中每个Key的额外开销,
+// nodes 为每个Key额外分配的节点大小, 供外部实现的淘汰策略复用
+func EntryOverhead[K comparable, V Sizer](nodes ...uintptr) int64 {
+	overhead := elementOverhead() + entityOverhead[K, V]() + mapEntryOverhead[K]()
+	for _, n := range nodes {
+		overhead += sizeClass(n)
+	}
+	return overhead
+}
@@ -0,0 +1,77 @@
+package generic
+
+import (
+	"runtime"
+	"strconv"
+	"testing"
+)
+
+// TestEstimateOverhead 估算的总占用与实际堆增长相差不超过25%
+func TestEstimateOverhead(t *testing.T) {
+	const n = 100000
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+	}
+	value := String("value")
+	caches := map[string]func() CacheMemory[string, String]{
+		"lru":    func() CacheMemory[string, String] { return NewLRUCache[string, String](0, nil) },
+		"lfu":    func() CacheMemory[string, String] { return NewLFUCache[string, String](0, nil) },
+		"fifo":   func() CacheMemory[string, String] { return NewFIFOCache[string, String](0, nil) },
+		"clock":  func() CacheMemory[string, String] { return NewClockCache[string, String](0, nil) },
+		"s3fifo": func() CacheMemory[string, String] { return NewS3FIFOCache[string, String](0, nil) },
+	}
+	for name, newCache := range caches {
+		t.Run(name, func(t *testing.T) {
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+			cache := newCache()
+			defer cache.Stop()
+			for _, key := range keys {
+				cache.SetWithoutTTL(key, value)
+			}
+			runtime.GC()
+			runtime.ReadMemStats(&after)
+			measured := int64(after.HeapAlloc - before.HeapAlloc)
+			cache.SetOverhead(cache.EstimateOverhead())
+			// Key 的字符串数据在缓存外分配, 不计入实际增长
+			estimated := cache.Stats().Bytes - int64(n*len(value)) - sumKeys(keys)
+			t.Logf("measured %d, estimated %d, overhead %d", measured/n, estimated/n, cache.EstimateOverhead())
+			if estimated < measured*3/4 || estimated > measured*5/4 {
+				t.Fatalf("estimated %d bytes, measured %d bytes", estimated, measured)
+			}
+			runtime.KeepAlive(cache)
+		})
+	}
+}
+
+func sumKeys(keys []string) (n int64) {
+	for _, key := range keys {
+		n += int64(len(key))
+	}
+	return
+}
+
+func TestSetOverhead(t *testing.T) {
+	for name, cache := range newAllCaches[string, String](int64(0), nil) {
+		t.Run(name, func(t *testing.T) {
+			defer cache.Stop()
+			cache.SetWithoutTTL("k1", String("v1"))
+			cache.SetWithoutTTL("k2", String("v2"))
+			cache.SetOverhead(100)
+			if stats := cache.Stats(); stats.Bytes != 208 || stats.Overhead != 100 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+			cache.SetLimit(300, 0)
+			cache.SetWithoutTTL("k3", String("v3"))
+			if l := cache.Len(); l != 2 {
+				t.Fatalf("expect len 2, got %d", l)
+			}
+			cache.SetOverhead(0)
+			if stats := cache.Stats(); stats.Bytes != 8 {
+				t.Fatalf("unexpected stats %+v", stats)
+			}
+		})
+	}
+}
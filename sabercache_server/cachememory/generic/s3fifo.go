@@ -0,0 +1,411 @@
+package generic
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// s3MaxFreq 访问次数上限
+const s3MaxFreq = 3
+
+// S3FIFOCache S3-FIFO 淘汰策略
+// 新Key写入 small 队列(容量的10%), 淘汰 small 队尾时被访问过的Key移入 main 队列, 否则淘汰并记入 ghost 队列;
+// 命中 ghost 的Key直接写入 main。main 队尾的Key访问次数不为0时减1后移回队头, 否则淘汰。
+// 命中时只在读锁下原子地增加访问次数, 不移动链表节点。ghost 只记录Key与大小, 不超过 main 的容量。
+type S3FIFOCache[K comparable, V Sizer] struct {
+	capacity   int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries int   // Key 数量上限, 0 表示不限制
+	length     int64 // Cache 当前容量(Byte), 即 small 与 main 之和
+	smallLen   int64
+	ghostLen   int64
+	overhead   int64      // 每个Key额外计入的字节数
+	small      *list.List // 链头表示最近写入
+	main       *list.List
+	ghost      *list.List
+	hashmap    map[K]*list.Element // small 与 main 中的Key
+	ghostmap   map[K]*list.Element
+	wheel      *TimingWheel[K]
+	mu         sync.RWMutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	callback   OnEliminated[K, V]
+}
+
+type s3Entry[K comparable, V Sizer] struct {
+	Entity[K, V]
+	freq   atomic.Int32
+	inMain bool
+}
+
+type s3Ghost[K comparable] struct {
+	key  K
+	size int64
+}
+
+func NewS3FIFOCache[K comparable, V Sizer](maxBytes int64, callback OnEliminated[K, V]) *S3FIFOCache[K, V] {
+	c := &S3FIFOCache[K, V]{
+		capacity: maxBytes,
+		small:    list.New(),
+		main:     list.New(),
+		ghost:    list.New(),
+		hashmap:  make(map[K]*list.Element),
+		ghostmap: make(map[K]*list.Element),
+		wheel:    NewTimingWheel[K](DefaultTick, SystemClock),
+		callback: callback,
+		stop:     make(chan struct{}),
+	}
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+// Get 从缓存获取对应Key的Value。
+// ok 指明查询结果 false代表查无此Key
+func (c *S3FIFOCache[K, V]) Get(key K) (value V, ok bool) {
+	c.mu.RLock()
+	if elem, ok := c.hashmap[key]; ok {
+		entry := elem.Value.(*s3Entry[K, V])
+		if entry.ExpiredTime != -1 && entry.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.RUnlock()
+			c.RemoveExpiredKey(key)
+			return value, false
+		}
+		entry.touch()
+		value = entry.Value
+		sliding := entry.Sliding > 0
+		c.mu.RUnlock()
+		if sliding {
+			c.slide(key)
+		}
+		return value, true
+	}
+	c.mu.RUnlock()
+	return
+}
+
+// touch 访问次数加1, 不超过 s3MaxFreq
+func (e *s3Entry[K, V]) touch() {
+	for {
+		freq := e.freq.Load()
+		if freq >= s3MaxFreq || e.freq.CompareAndSwap(freq, freq+1) {
+			return
+		}
+	}
+}
+
+// slide 顺延滑动过期Key的到期时间, Get 只持有读锁, 需另外加写锁
+func (c *S3FIFOCache[K, V]) slide(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		slideExpire(&elem.Value.(*s3Entry[K, V]).Entity)
+	}
+}
+
+func (c *S3FIFOCache[K, V]) GetAll() (kv []*Entity[K, V]) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, elem := range c.hashmap {
+		entry := elem.Value.(*s3Entry[K, V])
+		if entry.ExpiredTime != -1 && entry.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, &entry.Entity)
+	}
+	return
+}
+
+func (c *S3FIFOCache[K, V]) SetWithoutTTL(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0) {
+		c.wheel.Remove(key)
+	}
+}
+
+func (c *S3FIFOCache[K, V]) SetWithTTL(key K, value V, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *S3FIFOCache[K, V]) SetWithPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *S3FIFOCache[K, V]) SetWithSlidingPTTL(key K, value V, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *S3FIFOCache[K, V]) set(key K, value V, expireTime, sliding int64) bool {
+	kvSize := keySize(key) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	if elem, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值, 视为一次访问, 先取下再淘汰避免淘汰自身
+		entry := elem.Value.(*s3Entry[K, V])
+		c.detach(elem)
+		for c.overflow(kvSize, 1) && c.evict() {
+		}
+		old := entry.Value
+		entry.Value = value
+		entry.ExpiredTime, entry.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		entry.touch()
+		c.attach(entry)
+		return true
+	}
+	entry := &s3Entry[K, V]{Entity: Entity[K, V]{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding}}
+	if elem, ok := c.ghostmap[key]; ok {
+		// 命中 ghost 队列, 直接写入 main
+		c.removeGhost(elem)
+		entry.inMain = true
+	}
+	// 新增缓存Key
+	for c.overflow(kvSize, 1) && c.evict() {
+	}
+	c.attach(entry)
+	c.trimGhost()
+	return true
+}
+
+func (c *S3FIFOCache[K, V]) size(entry *s3Entry[K, V]) int64 {
+	return keySize(entry.Key) + int64(entry.Value.Len()) + c.overhead
+}
+
+func (c *S3FIFOCache[K, V]) attach(entry *s3Entry[K, V]) {
+	size := c.size(entry)
+	if entry.inMain {
+		c.hashmap[entry.Key] = c.main.PushFront(entry)
+	} else {
+		c.hashmap[entry.Key] = c.small.PushFront(entry)
+		c.smallLen += size
+	}
+	c.length += size
+}
+
+func (c *S3FIFOCache[K, V]) detach(elem *list.Element) {
+	entry := elem.Value.(*s3Entry[K, V])
+	size := c.size(entry)
+	if entry.inMain {
+		c.main.Remove(elem)
+	} else {
+		c.small.Remove(elem)
+		c.smallLen -= size
+	}
+	delete(c.hashmap, entry.Key)
+	c.length -= size
+}
+
+// evict 淘汰一枚缓存, 返回是否有缓存被淘汰
+func (c *S3FIFOCache[K, V]) evict() bool {
+	for c.small.Len() > 0 || c.main.Len() > 0 {
+		if c.small.Len() > 0 && (c.main.Len() == 0 || c.exceed(c.smallLen, c.small.Len(), 10)) {
+			if c.evictSmall() {
+				return true
+			}
+		} else if c.evictMain() {
+			return true
+		}
+	}
+	return false
+}
+
+// evictSmall 处理 small 队尾: 被访问过的Key移入 main, 否则淘汰并记入 ghost
+func (c *S3FIFOCache[K, V]) evictSmall() bool {
+	elem := c.small.Back()
+	entry := elem.Value.(*s3Entry[K, V])
+	if entry.freq.Load() > 0 {
+		c.detach(elem)
+		entry.freq.Store(0)
+		entry.inMain = true
+		c.attach(entry)
+		return false
+	}
+	k, size := entry.Key, c.size(entry)
+	c.removeElement(elem, EvictCapacity)
+	c.ghostmap[k] = c.ghost.PushFront(&s3Ghost[K]{key: k, size: size})
+	c.ghostLen += size
+	return true
+}
+
+// evictMain 处理 main 队尾: 访问次数不为0时减1后移回队头, 否则淘汰
+func (c *S3FIFOCache[K, V]) evictMain() bool {
+	elem := c.main.Back()
+	entry := elem.Value.(*s3Entry[K, V])
+	if freq := entry.freq.Load(); freq > 0 {
+		entry.freq.Store(freq - 1)
+		c.main.MoveToFront(elem)
+		return false
+	}
+	c.removeElement(elem, EvictCapacity)
+	return true
+}
+
+func (c *S3FIFOCache[K, V]) removeGhost(elem *list.Element) {
+	ghost := elem.Value.(*s3Ghost[K])
+	c.ghost.Remove(elem)
+	c.ghostLen -= ghost.size
+	delete(c.ghostmap, ghost.key)
+}
+
+// trimGhost 限制 ghost 队列不超过 main 的容量, 即容量上限的90%
+func (c *S3FIFOCache[K, V]) trimGhost() {
+	for c.ghost.Len() > 0 && (c.exceed(c.ghostLen, c.ghost.Len(), 90) || (c.capacity == 0 && c.maxEntries == 0)) {
+		c.removeGhost(c.ghost.Back())
+	}
+}
+
+// exceed bytes 字节、entries 个Key是否超过容量上限的 percent%
+func (c *S3FIFOCache[K, V]) exceed(bytes int64, entries int, percent int) bool {
+	return (c.capacity > 0 && bytes*100 >= c.capacity*int64(percent)) ||
+		(c.maxEntries > 0 && entries*100 >= c.maxEntries*percent)
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *S3FIFOCache[K, V]) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+
+// RemoveExpiredKey 移除过期Key, 过期Key不进入 ghost 队列
+func (c *S3FIFOCache[K, V]) RemoveExpiredKey(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在
+func (c *S3FIFOCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *S3FIFOCache[K, V]) MultiDeleteKey(keys []K, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *S3FIFOCache[K, V]) removeKey(key K, reason EvictReason) bool {
+	if elem, ok := c.hashmap[key]; ok {
+		c.removeElement(elem, reason)
+		return true
+	}
+	return false
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *S3FIFOCache[K, V]) expireKey(key K) {
+	if elem, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, &elem.Value.(*s3Entry[K, V]).Entity) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+func (c *S3FIFOCache[K, V]) removeElement(elem *list.Element, reason EvictReason) {
+	entry := elem.Value.(*s3Entry[K, V])
+	k, v := entry.Key, entry.Value
+	c.detach(elem)    // 移除缓存与映射
+	c.wheel.Remove(k) // 移除定时器
+	// 移除后的善后处理
+	if c.callback != nil {
+		c.callback(k, v, reason)
+	}
+}
+
+// Remove 按S3-FIFO策略淘汰一枚缓存
+func (c *S3FIFOCache[K, V]) Remove() {
+	c.evict()
+}
+
+func (c *S3FIFOCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.small.Len() + c.main.Len()
+}
+
+// overflow 再写入 size 字节、n 个Key后是否超出容量上限
+func (c *S3FIFOCache[K, V]) overflow(size int64, n int) bool {
+	return (c.capacity > 0 && c.length+size > c.capacity) ||
+		(c.maxEntries > 0 && c.small.Len()+c.main.Len()+n > c.maxEntries)
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *S3FIFOCache[K, V]) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	for c.overflow(0, 0) && c.evict() {
+	}
+	c.trimGhost()
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰
+func (c *S3FIFOCache[K, V]) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delta := overhead - c.overhead
+	c.smallLen += delta * int64(c.small.Len())
+	c.length += delta * int64(c.small.Len()+c.main.Len())
+	c.overhead = overhead
+	for c.overflow(0, 0) && c.evict() {
+	}
+	c.trimGhost()
+}
+
+// EstimateOverhead 估算每个Key在链表节点、s3Entry 与 map 中的额外开销, 不含 ghost 队列
+func (c *S3FIFOCache[K, V]) EstimateOverhead() int64 {
+	return EntryOverhead[K, V]() - entityOverhead[K, V]() + sizeClass(unsafe.Sizeof(s3Entry[K, V]{}))
+}
+
+func (c *S3FIFOCache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return Stats{Bytes: c.length, Entries: c.small.Len() + c.main.Len(), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+
+func (c *S3FIFOCache[K, V]) TTL(key K) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *S3FIFOCache[K, V]) PTTL(key K) int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if elem, ok := c.hashmap[key]; ok {
+		return remainingPTTL(elem.Value.(*s3Entry[K, V]).ExpiredTime)
+	}
+	return -2
+}
+
+func (c *S3FIFOCache[K, V]) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *S3FIFOCache[K, V]) Stop() {
+	c.Close()
+}
+
+var _ CacheMemory[string, Sizer] = (*S3FIFOCache[string, Sizer])(nil)
@@ -0,0 +1,50 @@
+package generic
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestS3FIFOOneHit 只访问一次的Key从 small 队列淘汰, 不影响 main 队列
+func TestS3FIFOOneHit(t *testing.T) {
+	var keys []string
+	cache := NewS3FIFOCache[string, String](int64(40), func(key string, value String, reason EvictReason) {
+		keys = append(keys, key)
+	})
+	defer cache.Stop()
+	for _, k := range []string{"h1", "h2", "h3"} {
+		cache.SetWithoutTTL(k, String("vv"))
+		cache.Get(k)
+	}
+	// 扫描写入的Key只访问一次
+	for _, k := range []string{"s1", "s2", "s3", "s4", "s5", "s6", "s7", "s8"} {
+		cache.SetWithoutTTL(k, String("vv"))
+	}
+	for _, k := range []string{"h1", "h2", "h3"} {
+		if _, ok := cache.Get(k); !ok {
+			t.Fatalf("%s should be kept, evicted %v", k, keys)
+		}
+	}
+	if expect := []string{"s1", "s2", "s3", "s4", "s5", "s6", "s7", "s8"}[:len(keys)]; !reflect.DeepEqual(expect, keys) {
+		t.Fatalf("expect evicted %v, got %v", expect, keys)
+	}
+}
+
+// TestS3FIFOGhost 命中 ghost 队列的Key直接写入 main 队列
+func TestS3FIFOGhost(t *testing.T) {
+	cache := NewS3FIFOCache[string, String](int64(40), nil)
+	defer cache.Stop()
+	for _, k := range []string{"k1", "k2", "k3", "k4", "k5", "k6", "k7", "k8", "k9", "k10", "k11", "k12"} {
+		cache.SetWithoutTTL(k, String("vv"))
+	}
+	if _, ok := cache.ghostmap["k1"]; !ok {
+		t.Fatalf("k1 should be in ghost")
+	}
+	cache.SetWithoutTTL("k1", String("vv"))
+	if entry := cache.hashmap["k1"].Value.(*s3Entry[string, String]); !entry.inMain {
+		t.Fatalf("k1 should be in main")
+	}
+	if _, ok := cache.ghostmap["k1"]; ok {
+		t.Fatalf("k1 should be removed from ghost")
+	}
+}
@@ -0,0 +1,181 @@
+package generic
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	wheelBits   = 6
+	wheelSlots  = 1 << wheelBits
+	wheelMask   = wheelSlots - 1
+	wheelLevels = 5
+)
+
+// DefaultTick 时间轮默认精度
+const DefaultTick = 10 * time.Millisecond
+
+// Clock 时间源, 测试时可替换
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock 系统时间
+var SystemClock Clock = systemClock{}
+
+type timer[K comparable] struct {
+	key    K
+	expire int64 // 到期的tick
+	slot   *list.List
+}
+
+// TimingWheel 分层时间轮
+// 共 wheelLevels 层, 每层 wheelSlots 个槽位, 第 i 层每个槽位跨越 wheelSlots^i 个tick,
+// 低层转完一圈时将高层对应槽位中的定时器降级到低层。
+// Advance 根据时钟推进到当前时间, 中间错过的tick会依次补上, 因此定时器抖动或GC停顿
+// 都不会导致到期Key被遗漏。TimingWheel 本身不加锁, 由所属的缓存加锁保护。
+type TimingWheel[K comparable] struct {
+	tick   time.Duration
+	clock  Clock
+	cur    int64 // 当前已处理到的tick
+	slots  [wheelLevels][wheelSlots]*list.List
+	timers map[K]*list.Element
+}
+
+func NewTimingWheel[K comparable](tick time.Duration, clock Clock) *TimingWheel[K] {
+	if tick <= 0 {
+		tick = DefaultTick
+	}
+	if clock == nil {
+		clock = SystemClock
+	}
+	tw := &TimingWheel[K]{
+		tick:   tick,
+		clock:  clock,
+		timers: make(map[K]*list.Element),
+	}
+	for i := range tw.slots {
+		for j := range tw.slots[i] {
+			tw.slots[i][j] = list.New()
+		}
+	}
+	tw.cur = tw.toTick(clock.Now())
+	return tw
+}
+
+func (tw *TimingWheel[K]) toTick(t time.Time) int64 {
+	return t.UnixNano() / int64(tw.tick)
+}
+
+// Add 为Key设置到期时间, 已存在的定时器会被替换
+func (tw *TimingWheel[K]) Add(key K, expireAt time.Time) {
+	tw.Remove(key)
+	// 向上取整, 保证到期时Key一定已经过期
+	expire := (expireAt.UnixNano() + int64(tw.tick) - 1) / int64(tw.tick)
+	if expire <= tw.cur {
+		expire = tw.cur + 1
+	}
+	t := &timer[K]{key: key, expire: expire}
+	tw.timers[key] = tw.place(t)
+}
+
+// place 按到期tick与当前tick的差值放入对应层的槽位
+func (tw *TimingWheel[K]) place(t *timer[K]) *list.Element {
+	expire := t.expire
+	if expire < tw.cur {
+		expire = tw.cur
+	}
+	delta := expire - tw.cur
+	if delta >= 1<<(wheelBits*wheelLevels) {
+		// 超出时间轮范围, 先放在最高层, 降级时重新计算位置
+		expire = tw.cur + 1<<(wheelBits*wheelLevels) - 1
+		delta = expire - tw.cur
+	}
+	level := 0
+	for level < wheelLevels-1 && delta >= 1<<(wheelBits*(level+1)) {
+		level++
+	}
+	t.slot = tw.slots[level][(expire>>(wheelBits*level))&wheelMask]
+	return t.slot.PushBack(t)
+}
+
+// Remove 删除Key的定时器
+func (tw *TimingWheel[K]) Remove(key K) {
+	if elem, ok := tw.timers[key]; ok {
+		elem.Value.(*timer[K]).slot.Remove(elem)
+		delete(tw.timers, key)
+	}
+}
+
+// Advance 推进到时钟的当前时间, 返回期间到期的Key
+func (tw *TimingWheel[K]) Advance() []K {
+	return tw.advanceTo(tw.toTick(tw.clock.Now()))
+}
+
+func (tw *TimingWheel[K]) advanceTo(target int64) (expired []K) {
+	for tw.cur < target {
+		if len(tw.timers) == 0 {
+			tw.cur = target
+			break
+		}
+		tw.cur++
+		for level := 1; level < wheelLevels; level++ {
+			if tw.cur&(1<<(wheelBits*level)-1) != 0 {
+				break
+			}
+			tw.cascade(level, (tw.cur>>(wheelBits*level))&wheelMask)
+		}
+		slot := tw.slots[0][tw.cur&wheelMask]
+		for elem := slot.Front(); elem != nil; {
+			next := elem.Next()
+			t := elem.Value.(*timer[K])
+			slot.Remove(elem)
+			delete(tw.timers, t.key)
+			expired = append(expired, t.key)
+			elem = next
+		}
+	}
+	return
+}
+
+// cascade 将高层槽位中的定时器重新放入低层
+func (tw *TimingWheel[K]) cascade(level int, idx int64) {
+	slot := tw.slots[level][idx]
+	for elem := slot.Front(); elem != nil; {
+		next := elem.Next()
+		t := elem.Value.(*timer[K])
+		slot.Remove(elem)
+		tw.timers[t.key] = tw.place(t)
+		elem = next
+	}
+}
+
+// Len 返回定时器数量
+func (tw *TimingWheel[K]) Len() int {
+	return len(tw.timers)
+}
+
+// Run 每个tick推进一次时间轮, 持有锁调用 remove 移除到期Key, 直到 stop 被关闭
+func (tw *TimingWheel[K]) Run(mu sync.Locker, stop <-chan struct{}, remove func(key K)) {
+	t := time.NewTicker(tw.tick)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			mu.Lock()
+			for _, key := range tw.Advance() {
+				remove(key)
+			}
+			mu.Unlock()
+		case <-stop:
+			return
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package generic
+
+import (
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1700000000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestTimingWheelExpire(t *testing.T) {
+	clock := newFakeClock()
+	tw := NewTimingWheel[string](time.Second, clock)
+	tw.Add("k1", clock.Now().Add(1*time.Second))
+	tw.Add("k2", clock.Now().Add(100*time.Second))
+	tw.Add("k3", clock.Now().Add(5000*time.Second))
+	tw.Add("k4", clock.Now().Add(-time.Second))
+
+	clock.Add(time.Second)
+	if keys := tw.Advance(); !equalKeys(keys, "k1", "k4") {
+		t.Fatalf("expect k1,k4 expired, got %v", keys)
+	}
+	clock.Add(98 * time.Second)
+	if keys := tw.Advance(); len(keys) != 0 {
+		t.Fatalf("expect nothing expired, got %v", keys)
+	}
+	clock.Add(time.Second)
+	if keys := tw.Advance(); !equalKeys(keys, "k2") {
+		t.Fatalf("expect k2 expired, got %v", keys)
+	}
+	clock.Add(4899 * time.Second)
+	if keys := tw.Advance(); len(keys) != 0 || tw.Len() != 1 {
+		t.Fatalf("expect nothing expired, got %v", keys)
+	}
+	clock.Add(time.Second)
+	if keys := tw.Advance(); !equalKeys(keys, "k3") || tw.Len() != 0 {
+		t.Fatalf("expect k3 expired, got %v", keys)
+	}
+}
+
+func TestTimingWheelRemove(t *testing.T) {
+	clock := newFakeClock()
+	tw := NewTimingWheel[string](time.Second, clock)
+	tw.Add("k1", clock.Now().Add(2*time.Second))
+	tw.Add("k2", clock.Now().Add(2*time.Second))
+	tw.Remove("k1")
+	// 重新设置到期时间会替换原定时器
+	tw.Add("k2", clock.Now().Add(10*time.Second))
+	clock.Add(5 * time.Second)
+	if keys := tw.Advance(); len(keys) != 0 {
+		t.Fatalf("expect nothing expired, got %v", keys)
+	}
+	clock.Add(5 * time.Second)
+	if keys := tw.Advance(); !equalKeys(keys, "k2") {
+		t.Fatalf("expect k2 expired, got %v", keys)
+	}
+}
+
+// TestTimingWheelCatchUp 时钟跳跃(如GC停顿)后, 期间到期的Key一次性全部返回
+func TestTimingWheelCatchUp(t *testing.T) {
+	clock := newFakeClock()
+	tw := NewTimingWheel[string](10*time.Millisecond, clock)
+	for i := 0; i < 10000; i++ {
+		tw.Add(strconv.Itoa(i), clock.Now().Add(time.Duration(rand.Int63n(int64(time.Hour)))))
+	}
+	tw.Add("later", clock.Now().Add(3*time.Hour))
+	clock.Add(2 * time.Hour)
+	if keys := tw.Advance(); len(keys) != 10000 {
+		t.Fatalf("expect 10000 keys expired, got %d", len(keys))
+	}
+	clock.Add(time.Hour)
+	if keys := tw.Advance(); !equalKeys(keys, "later") {
+		t.Fatalf("expect later expired, got %v", keys)
+	}
+}
+
+// TestTimingWheelAccuracy Key不会提前到期, 且最迟在到期后一个tick内被返回
+func TestTimingWheelAccuracy(t *testing.T) {
+	clock := newFakeClock()
+	tick := 100 * time.Millisecond
+	tw := NewTimingWheel[string](tick, clock)
+	expireAt := make(map[string]time.Time)
+	for i := 0; i < 2000; i++ {
+		key := strconv.Itoa(i)
+		expireAt[key] = clock.Now().Add(time.Duration(rand.Int63n(int64(20 * time.Minute))))
+		tw.Add(key, expireAt[key])
+	}
+	for tw.Len() > 0 {
+		clock.Add(tick)
+		now := clock.Now()
+		for _, key := range tw.Advance() {
+			if expireAt[key].After(now) {
+				t.Fatalf("key %s expired too early", key)
+			}
+			if now.Sub(expireAt[key]) >= tick {
+				t.Fatalf("key %s expired too late", key)
+			}
+			delete(expireAt, key)
+		}
+	}
+	if len(expireAt) != 0 {
+		t.Fatalf("%d keys never expired", len(expireAt))
+	}
+}
+
+func equalKeys(keys []string, expect ...string) bool {
+	if len(keys) != len(expect) {
+		return false
+	}
+	sort.Strings(keys)
+	sort.Strings(expect)
+	for i := range keys {
+		if keys[i] != expect[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkTimingWheelAdd(b *testing.B) {
+	clock := newFakeClock()
+	tw := NewTimingWheel[string](DefaultTick, clock)
+	keys := make([]string, 1<<20)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tw.Add(keys[i&(1<<20-1)], clock.Now().Add(time.Duration(i&4095)*time.Second))
+	}
+}
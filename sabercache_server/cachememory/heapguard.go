@@ -0,0 +1,176 @@
+package cachememory
+
+import (
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultHeapCheckInterval 堆内存检查的默认间隔
+const DefaultHeapCheckInterval = time.Second
+
+// heapGCTicks 超过水位期间每隔多少个检查间隔最多触发一次GC
+const heapGCTicks = 10
+
+// Limiter 可调整容量上限的缓存
+type Limiter interface {
+	SetLimit(maxBytes int64, maxEntries int)
+	Stats() Stats
+}
+
+// HeapGuard 堆内存水位保护, 一个进程只需一个, 同时作用于通过 Add 加入的所有缓存。
+// 定期读取 runtime.MemStats, 堆内存超过水位时触发GC(每 heapGCTicks 个检查间隔最多一次), 仍超过则将超出的字节数
+// 按各缓存的占用比例分摊, 调低各自的容量上限, 由缓存自身完成淘汰; 堆内存回落到水位的90%以下后逐步恢复到原有的容量上限,
+// 原本不限制容量的缓存直接恢复为不限制。
+type HeapGuard struct {
+	mu        sync.Mutex
+	caches    map[Limiter]int64 // 缓存 -> 原有的容量上限
+	limit     uint64            // 堆内存水位(Byte)
+	interval  time.Duration
+	heapAlloc func() uint64
+	lastGC    time.Time
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewHeapGuard interval 小于等于0时取默认值
+func NewHeapGuard(limit uint64, interval time.Duration) *HeapGuard {
+	g := newHeapGuard(limit, interval, readHeapAlloc)
+	go g.Run()
+	return g
+}
+
+func newHeapGuard(limit uint64, interval time.Duration, heapAlloc func() uint64) *HeapGuard {
+	if interval <= 0 {
+		interval = DefaultHeapCheckInterval
+	}
+	return &HeapGuard{
+		caches:    make(map[Limiter]int64),
+		limit:     limit,
+		interval:  interval,
+		heapAlloc: heapAlloc,
+		stop:      make(chan struct{}),
+	}
+}
+
+func readHeapAlloc() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// Add 将缓存加入水位保护, 记录其当前的容量上限作为恢复的目标
+func (g *HeapGuard) Add(cache Limiter) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if _, ok := g.caches[cache]; !ok {
+		g.caches[cache] = cache.Stats().MaxBytes
+	}
+}
+
+// Remove 将缓存移出水位保护并恢复其原有的容量上限, 返回剩余的缓存数量
+func (g *HeapGuard) Remove(cache Limiter) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if maxBytes, ok := g.caches[cache]; ok {
+		if stats := cache.Stats(); stats.MaxBytes != maxBytes {
+			cache.SetLimit(maxBytes, stats.MaxEntries)
+		}
+		delete(g.caches, cache)
+	}
+	return len(g.caches)
+}
+
+// Run 每隔 interval 检查一次堆内存, 直到 Stop 被调用
+func (g *HeapGuard) Run() {
+	t := time.NewTicker(g.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			g.check()
+		case <-g.stop:
+			return
+		}
+	}
+}
+
+func (g *HeapGuard) check() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	heap := g.heapAlloc()
+	if heap > g.limit && time.Since(g.lastGC) >= heapGCTicks*g.interval {
+		// 已淘汰的Key在GC前仍占用堆内存, 先回收再判断
+		runtime.GC()
+		g.lastGC = time.Now()
+		heap = g.heapAlloc()
+	}
+	low := g.limit / 10 * 9
+	switch {
+	case heap > g.limit:
+		g.shrink(int64(heap - g.limit))
+	case heap < low:
+		g.grow(int64(low - heap))
+	}
+}
+
+// shrink 按各缓存的占用比例分摊超出的字节数, 需持有锁
+func (g *HeapGuard) shrink(excess int64) {
+	stats := make(map[Limiter]Stats, len(g.caches))
+	var total int64
+	for cache := range g.caches {
+		st := cache.Stats()
+		stats[cache] = st
+		total += st.Bytes
+	}
+	if total == 0 {
+		return
+	}
+	for cache, st := range stats {
+		if st.Bytes == 0 {
+			continue
+		}
+		target := st.Bytes - int64(float64(excess)*float64(st.Bytes)/float64(total))
+		if target < 1 {
+			target = 1
+		}
+		if st.MaxBytes == 0 || target < st.MaxBytes {
+			cache.SetLimit(target, st.MaxEntries)
+		}
+	}
+}
+
+// grow 按各缓存与原有容量上限的差距分摊可恢复的字节数, 需持有锁
+func (g *HeapGuard) grow(room int64) {
+	stats := make(map[Limiter]Stats, len(g.caches))
+	var gap int64
+	for cache, maxBytes := range g.caches {
+		st := cache.Stats()
+		if maxBytes == 0 {
+			if st.MaxBytes != 0 {
+				// 无法逐步恢复到不限制, 直接恢复
+				cache.SetLimit(0, st.MaxEntries)
+			}
+			continue
+		}
+		if st.MaxBytes == 0 || st.MaxBytes >= maxBytes {
+			continue
+		}
+		stats[cache] = st
+		gap += maxBytes - st.MaxBytes
+	}
+	for cache, st := range stats {
+		maxBytes := g.caches[cache]
+		target := st.MaxBytes + int64(float64(room)*float64(maxBytes-st.MaxBytes)/float64(gap))
+		if target > maxBytes {
+			target = maxBytes
+		}
+		cache.SetLimit(target, st.MaxEntries)
+	}
+}
+
+func (g *HeapGuard) Stop() {
+	g.stopOnce.Do(func() {
+		close(g.stop)
+	})
+}
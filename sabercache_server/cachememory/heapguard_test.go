@@ -0,0 +1,107 @@
+package cachememory
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestHeapGuard(t *testing.T) {
+	cache := NewLRUCache(int64(1000), nil)
+	defer cache.Stop()
+	for i := 0; i < 100; i++ {
+		cache.SetWithoutTTL("key"+strconv.Itoa(i), String("value"))
+	}
+	heap := uint64(0)
+	g := newHeapGuard(10000, 0, func() uint64 { return heap })
+	g.Add(cache)
+
+	t.Run("低于水位", func(t *testing.T) {
+		heap = 9500
+		g.check()
+		if stats := cache.Stats(); stats.MaxBytes != 1000 || stats.Entries != 100 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+	t.Run("超过水位", func(t *testing.T) {
+		// 当前占用990字节, 超出水位500字节
+		heap = 10500
+		g.check()
+		stats := cache.Stats()
+		if stats.MaxBytes != 490 || stats.Bytes > 490 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+	t.Run("逐步恢复", func(t *testing.T) {
+		heap = 8800
+		g.check()
+		if stats := cache.Stats(); stats.MaxBytes != 690 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+		heap = 5000
+		g.check()
+		if stats := cache.Stats(); stats.MaxBytes != 1000 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+}
+
+// TestHeapGuardUnlimited 缓存不限制容量时同样可以按水位淘汰, 回落后恢复为不限制
+func TestHeapGuardUnlimited(t *testing.T) {
+	cache := NewLRUCache(int64(0), nil)
+	defer cache.Stop()
+	for i := 0; i < 100; i++ {
+		cache.SetWithoutTTL("key"+strconv.Itoa(i), String("value"))
+	}
+	heap := uint64(20000)
+	g := newHeapGuard(10000, 0, func() uint64 { return heap })
+	g.Add(cache)
+	g.check()
+	if stats := cache.Stats(); stats.MaxBytes != 1 || stats.Entries != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	heap = 1000
+	g.check()
+	if stats := cache.Stats(); stats.MaxBytes != 0 || stats.Entries != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+// TestHeapGuardShared 多个缓存共用一个水位保护, 超出的字节数按占用比例分摊
+func TestHeapGuardShared(t *testing.T) {
+	small, large := NewLRUCache(int64(1000), nil), NewLRUCache(int64(0), nil)
+	defer small.Stop()
+	defer large.Stop()
+	for i := 0; i < 100; i++ {
+		key := "key" + strconv.Itoa(i)
+		if i < 25 {
+			small.SetWithoutTTL(key, String("value"))
+		}
+		large.SetWithoutTTL(key, String("value"))
+	}
+	// small 占用 240 字节, large 占用 990 字节, 超出水位的 615 字节各分摊一半
+	heap := uint64(10615)
+	g := newHeapGuard(10000, 0, func() uint64 { return heap })
+	g.Add(small)
+	g.Add(large)
+	g.check()
+	if stats := small.Stats(); stats.MaxBytes != 120 {
+		t.Fatalf("unexpected small stats %+v", stats)
+	}
+	if stats := large.Stats(); stats.MaxBytes != 495 {
+		t.Fatalf("unexpected large stats %+v", stats)
+	}
+	heap = 1000
+	g.check()
+	if small.Stats().MaxBytes != 1000 || large.Stats().MaxBytes != 0 {
+		t.Fatalf("limits not restored: %+v %+v", small.Stats(), large.Stats())
+	}
+	// 移出后不再受水位保护
+	if g.Remove(small) != 1 {
+		t.Fatalf("expect 1 cache left")
+	}
+	heap = 20000
+	g.check()
+	if small.Stats().MaxBytes != 1000 || large.Stats().MaxBytes != 1 {
+		t.Fatalf("unexpected limits %+v %+v", small.Stats(), large.Stats())
+	}
+}
@@ -0,0 +1,129 @@
+package cachememory
+
+import (
+	"container/list"
+	"sync"
+)
+
+// legacyLFUCache 重写前的LFU实现(去掉过期相关逻辑), 仅用于基准测试对比
+// 以双向链表按频率降序保存全部Key, freqmap 记录每个频率最近进入的元素
+type legacyLFUCache struct {
+	capacity         int64
+	length           int64
+	doublyLinkedList *list.List
+	hashmap          map[string]*list.Element
+	valuefreqmap     map[*list.Element]*legacyValueFreq
+	freqmap          map[int]*list.List
+	mu               sync.Mutex
+}
+
+type legacyValueFreq struct {
+	freq int
+	elem *list.Element
+}
+
+func newLegacyLFUCache(capacity int64) *legacyLFUCache {
+	return &legacyLFUCache{
+		capacity:         capacity,
+		doublyLinkedList: list.New(),
+		valuefreqmap:     make(map[*list.Element]*legacyValueFreq),
+		hashmap:          make(map[string]*list.Element),
+		freqmap:          make(map[int]*list.List),
+	}
+}
+
+func (c *legacyLFUCache) Get(key string) (Value, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		c.incr(elem)
+		return elem.Value.(*Entity).Value, true
+	}
+	return nil, false
+}
+
+func (c *legacyLFUCache) SetWithoutTTL(key string, value Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	kvSize := int64(len(key)) + int64(value.Len())
+	if kvSize > c.capacity {
+		return
+	}
+	if elem, ok := c.hashmap[key]; ok {
+		c.incr(elem)
+		oldEntry := elem.Value.(*Entity)
+		for c.capacity != 0 && c.length-int64(oldEntry.Value.Len())+int64(value.Len()) > c.capacity {
+			c.remove()
+		}
+		c.length += int64(value.Len()) - int64(oldEntry.Value.Len())
+		oldEntry.Value = value
+	} else {
+		for c.capacity != 0 && c.length+kvSize > c.capacity {
+			c.remove()
+		}
+		c.push(&Entity{Key: key, Value: value, ExpiredTime: -1})
+		c.length += kvSize
+	}
+}
+
+func (c *legacyLFUCache) incr(elem *list.Element) {
+	var moved bool
+	freq := c.valuefreqmap[elem].freq
+	e := c.valuefreqmap[elem].elem
+	freq++
+	if _, ok := c.freqmap[freq]; ok {
+		front := c.freqmap[freq].Back().Value.(*list.Element)
+		c.doublyLinkedList.MoveBefore(elem, front)
+		moved = true
+		e = c.freqmap[freq].PushBack(elem)
+	} else {
+		c.freqmap[freq] = list.New()
+		e = c.freqmap[freq].PushBack(elem)
+	}
+	if c.freqmap[c.valuefreqmap[elem].freq].Len() == 1 {
+		c.freqmap[c.valuefreqmap[elem].freq].Remove(c.valuefreqmap[elem].elem)
+		delete(c.freqmap, c.valuefreqmap[elem].freq)
+	} else {
+		if !moved {
+			front := c.freqmap[c.valuefreqmap[elem].freq].Back().Value.(*list.Element)
+			c.doublyLinkedList.MoveBefore(elem, front)
+		}
+		c.freqmap[c.valuefreqmap[elem].freq].Remove(c.valuefreqmap[elem].elem)
+	}
+	c.valuefreqmap[elem].freq++
+	c.valuefreqmap[elem].elem = e
+}
+
+func (c *legacyLFUCache) push(entity *Entity) {
+	if _, ok := c.freqmap[1]; !ok {
+		elem := c.doublyLinkedList.PushBack(entity)
+		c.hashmap[entity.Key] = elem
+		c.freqmap[1] = list.New()
+		e := c.freqmap[1].PushBack(elem)
+		c.valuefreqmap[elem] = &legacyValueFreq{1, e}
+	} else {
+		front := c.freqmap[1].Back().Value.(*list.Element)
+		elem := c.doublyLinkedList.InsertBefore(entity, front)
+		c.hashmap[entity.Key] = elem
+		e := c.freqmap[1].PushBack(elem)
+		c.valuefreqmap[elem] = &legacyValueFreq{1, e}
+	}
+}
+
+func (c *legacyLFUCache) remove() {
+	elem := c.doublyLinkedList.Back()
+	if elem == nil {
+		return
+	}
+	freq := c.valuefreqmap[elem].freq
+	e := c.valuefreqmap[elem].elem
+	delete(c.valuefreqmap, elem)
+	c.freqmap[freq].Remove(e)
+	if c.freqmap[freq].Front() == nil {
+		delete(c.freqmap, freq)
+	}
+	entity := elem.Value.(*Entity)
+	delete(c.hashmap, entity.Key)
+	c.doublyLinkedList.Remove(elem)
+	c.length -= int64(len(entity.Key)) + int64(entity.Value.Len())
+}
@@ -2,6 +2,7 @@ package cachememory
 
 import (
 	"fmt"
+	"math/rand"
 	"reflect"
 	"strconv"
 	"testing"
@@ -244,7 +245,7 @@ func TestLFUMaxSize(t *testing.T) {
 }
 func TestLFUOnEnvicted(t *testing.T) {
 	keys := make([]string, 0)
-	callback := func(key string, value Value) {
+	callback := func(key string, value Value, reason EvictReason) {
 		keys = append(keys, key)
 	}
 	var cache CacheMemory = NewLFUCache(int64(10), callback)
@@ -261,3 +262,64 @@ func TestLFUOnEnvicted(t *testing.T) {
 		t.Fatalf("Call OnEvicted failed, expect keys equals to %s", expect)
 	}
 }
+
+// TestLFUEvictOrder 淘汰访问次数最少的Key, 次数相同时淘汰最早进入的Key
+func TestLFUEvictOrder(t *testing.T) {
+	keys := make([]string, 0)
+	callback := func(key string, value Value, reason EvictReason) {
+		keys = append(keys, key)
+	}
+	cache := NewLFUCache(int64(12), callback)
+	defer cache.Stop()
+	cache.SetWithoutTTL("k1", String("v1"))
+	cache.SetWithoutTTL("k2", String("v2"))
+	cache.SetWithoutTTL("k3", String("v3"))
+	cache.Get("k1")
+	cache.Get("k1")
+	cache.Get("k2")
+	cache.Get("k3")
+	cache.SetWithoutTTL("k4", String("v4"))
+	cache.SetWithoutTTL("k5", String("v5"))
+	cache.SetWithoutTTL("k6", String("v6"))
+	expect := []string{"k2", "k4", "k5"}
+	if !reflect.DeepEqual(expect, keys) {
+		t.Fatalf("expect evicted %v, got %v", expect, keys)
+	}
+}
+
+// benchLFU 按Zipf分布读写, 未命中时写入
+func benchLFU(b *testing.B, get func(string) (Value, bool), set func(string, Value)) {
+	keys := make([]string, 1<<16)
+	for i := range keys {
+		keys[i] = strconv.Itoa(i)
+	}
+	zipf := rand.NewZipf(rand.New(rand.NewSource(1)), 1.01, 1, uint64(len(keys)-1))
+	trace := make([]string, 1<<20)
+	for i := range trace {
+		trace[i] = keys[zipf.Uint64()]
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := trace[i&(1<<20-1)]
+		if _, ok := get(key); !ok {
+			set(key, String("value"))
+		}
+	}
+}
+
+func BenchmarkLFU(b *testing.B) {
+	cache := NewLFUCache(int64(1<<16), nil)
+	defer cache.Stop()
+	benchLFU(b, cache.Get, cache.SetWithoutTTL)
+}
+
+func BenchmarkLFUWithDecay(b *testing.B) {
+	cache := NewLFUCacheWithDecay(int64(1<<16), time.Millisecond, nil)
+	defer cache.Stop()
+	benchLFU(b, cache.Get, cache.SetWithoutTTL)
+}
+
+func BenchmarkLegacyLFU(b *testing.B) {
+	cache := newLegacyLFUCache(int64(1 << 16))
+	benchLFU(b, cache.Get, cache.SetWithoutTTL)
+}
@@ -238,7 +238,7 @@ func TestLRUMaxSize(t *testing.T) {
 }
 func TestLRUOnEnvicted(t *testing.T) {
 	keys := make([]string, 0)
-	callback := func(key string, value Value) {
+	callback := func(key string, value Value, reason EvictReason) {
 		keys = append(keys, key)
 	}
 	var cache CacheMemory = NewLRUCache(int64(10), callback)
@@ -0,0 +1,311 @@
+package cachememory
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// NamespaceSep 分隔命名空间与Key, 默认命名空间的Key不带前缀
+const NamespaceSep = "\x00"
+
+// NamespaceKey 返回命名空间 ns 中的Key在缓存中的存储Key, key 不能包含 NamespaceSep
+func NamespaceKey(ns, key string) string {
+	if ns == "" {
+		return key
+	}
+	return ns + NamespaceSep + key
+}
+
+// SplitNamespace 将存储Key拆分为命名空间与Key
+func SplitNamespace(key string) (ns, rawKey string) {
+	if i := strings.Index(key, NamespaceSep); i >= 0 {
+		return key[:i], key[i+len(NamespaceSep):]
+	}
+	return "", key
+}
+
+// NamespacedCache 多租户缓存
+// 每个命名空间拥有独立的 CacheMemory 与字节配额, 只在自己的配额内淘汰, 批量写入不会挤占其他命名空间。
+// 配额从总容量中划出, 默认命名空间使用剩余的容量; 总容量被调低(如堆内存水位保护)到不足以容纳各配额时按比例缩小。
+// 未注册的命名空间的Key归入默认命名空间。
+type NamespacedCache struct {
+	mu           sync.RWMutex
+	def          CacheMemory
+	namespaces   map[string]*namespace
+	newPartition func(maxBytes int64) CacheMemory
+	maxBytes     int64 // 总容量上限, 0 表示不限制
+	maxEntries   int   // 默认命名空间的Key数量上限
+	overhead     int64
+}
+
+type namespace struct {
+	cache CacheMemory
+	quota int64
+}
+
+// NewNamespacedCache newPartition 根据容量创建单个命名空间的缓存, 可以是任意淘汰策略
+func NewNamespacedCache(maxBytes int64, newPartition func(maxBytes int64) CacheMemory) *NamespacedCache {
+	return &NamespacedCache{
+		def:          newPartition(maxBytes),
+		namespaces:   make(map[string]*namespace),
+		newPartition: newPartition,
+		maxBytes:     maxBytes,
+	}
+}
+
+// SetQuota 设置命名空间 ns 的字节配额, 命名空间不存在时创建; 各配额之和需小于总容量
+func (c *NamespacedCache) SetQuota(ns string, quota int64) error {
+	if ns == "" || strings.Contains(ns, NamespaceSep) {
+		return fmt.Errorf("invalid namespace %q", ns)
+	}
+	if quota <= 0 {
+		return fmt.Errorf("quota of namespace %s must be positive", ns)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.maxBytes > 0 {
+		sum := quota
+		for name, n := range c.namespaces {
+			if name != ns {
+				sum += n.quota
+			}
+		}
+		if sum >= c.maxBytes {
+			return fmt.Errorf("total quota %d exceeds capacity %d", sum, c.maxBytes)
+		}
+	}
+	n, ok := c.namespaces[ns]
+	if !ok {
+		n = &namespace{cache: c.newPartition(quota)}
+		n.cache.SetOverhead(c.overhead)
+		c.namespaces[ns] = n
+	}
+	n.quota = quota
+	c.resize()
+	return nil
+}
+
+// HasNamespace 默认命名空间始终存在
+func (c *NamespacedCache) HasNamespace(ns string) bool {
+	if ns == "" {
+		return true
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	_, ok := c.namespaces[ns]
+	return ok
+}
+
+// Namespaces 返回已注册的命名空间, 不含默认命名空间
+func (c *NamespacedCache) Namespaces() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	names := make([]string, 0, len(c.namespaces))
+	for name := range c.namespaces {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NamespaceStats 返回各命名空间的统计, 默认命名空间的键为空字符串
+func (c *NamespacedCache) NamespaceStats() map[string]Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats := map[string]Stats{"": c.def.Stats()}
+	for name, n := range c.namespaces {
+		stats[name] = n.cache.Stats()
+	}
+	return stats
+}
+
+// resize 按总容量重新分配各命名空间的容量上限, 需持有写锁
+func (c *NamespacedCache) resize() {
+	if c.maxBytes <= 0 {
+		c.def.SetLimit(0, c.maxEntries)
+		for _, n := range c.namespaces {
+			n.cache.SetLimit(n.quota, 0)
+		}
+		return
+	}
+	var sum int64
+	for _, n := range c.namespaces {
+		sum += n.quota
+	}
+	if sum < c.maxBytes {
+		c.def.SetLimit(c.maxBytes-sum, c.maxEntries)
+		for _, n := range c.namespaces {
+			n.cache.SetLimit(n.quota, 0)
+		}
+		return
+	}
+	// 默认命名空间按1个配额单位参与缩放, 保证每个命名空间至少1字节
+	total := sum + 1
+	c.def.SetLimit(max64(c.maxBytes/total, 1), c.maxEntries)
+	for _, n := range c.namespaces {
+		n.cache.SetLimit(max64(n.quota*c.maxBytes/total, 1), 0)
+	}
+}
+
+func (c *NamespacedCache) partition(key string) CacheMemory {
+	ns, _ := SplitNamespace(key)
+	if ns == "" {
+		return c.def
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if n, ok := c.namespaces[ns]; ok {
+		return n.cache
+	}
+	return c.def
+}
+
+// partitions 返回包括默认命名空间在内的全部命名空间的缓存
+func (c *NamespacedCache) partitions() []CacheMemory {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	ps := make([]CacheMemory, 0, len(c.namespaces)+1)
+	ps = append(ps, c.def)
+	for _, n := range c.namespaces {
+		ps = append(ps, n.cache)
+	}
+	return ps
+}
+
+func (c *NamespacedCache) Get(key string) (Value, bool) {
+	return c.partition(key).Get(key)
+}
+
+func (c *NamespacedCache) GetAll() (kv []*Entity) {
+	for _, p := range c.partitions() {
+		kv = append(kv, p.GetAll()...)
+	}
+	return
+}
+
+func (c *NamespacedCache) SetWithoutTTL(key string, value Value) {
+	c.partition(key).SetWithoutTTL(key, value)
+}
+
+func (c *NamespacedCache) SetWithTTL(key string, value Value, ttl int64) {
+	c.partition(key).SetWithTTL(key, value, ttl)
+}
+
+func (c *NamespacedCache) SetWithPTTL(key string, value Value, pttl int64) {
+	c.partition(key).SetWithPTTL(key, value, pttl)
+}
+
+func (c *NamespacedCache) SetWithSlidingPTTL(key string, value Value, pttl int64) {
+	c.partition(key).SetWithSlidingPTTL(key, value, pttl)
+}
+
+// SetWithoutTTLCost 命名空间不支持代价感知时忽略 cost
+func (c *NamespacedCache) SetWithoutTTLCost(key string, value Value, cost int64) {
+	p := c.partition(key)
+	if cs, ok := p.(CostSetter); ok {
+		cs.SetWithoutTTLCost(key, value, cost)
+		return
+	}
+	p.SetWithoutTTL(key, value)
+}
+
+// SetWithPTTLCost 命名空间不支持代价感知时忽略 cost
+func (c *NamespacedCache) SetWithPTTLCost(key string, value Value, pttl int64, cost int64) {
+	p := c.partition(key)
+	if cs, ok := p.(CostSetter); ok {
+		cs.SetWithPTTLCost(key, value, pttl, cost)
+		return
+	}
+	p.SetWithPTTL(key, value, pttl)
+}
+
+// ExpireKeyMonitor 各命名空间在创建时已启动各自的过期监控, 此处无需处理
+func (c *NamespacedCache) ExpireKeyMonitor() {}
+
+func (c *NamespacedCache) MultiDeleteKey(keys []string, t int64) {
+	group := make(map[CacheMemory][]string)
+	for _, key := range keys {
+		p := c.partition(key)
+		group[p] = append(group[p], key)
+	}
+	for p, keys := range group {
+		p.MultiDeleteKey(keys, t)
+	}
+}
+
+func (c *NamespacedCache) RemoveExpiredKey(key string) {
+	c.partition(key).RemoveExpiredKey(key)
+}
+
+func (c *NamespacedCache) Delete(key string) bool {
+	return c.partition(key).Delete(key)
+}
+
+// Remove 从默认命名空间淘汰一枚缓存, 各命名空间只在自己的配额内淘汰
+func (c *NamespacedCache) Remove() {
+	c.def.Remove()
+}
+
+func (c *NamespacedCache) TTL(key string) int64 {
+	return c.partition(key).TTL(key)
+}
+
+func (c *NamespacedCache) PTTL(key string) int64 {
+	return c.partition(key).PTTL(key)
+}
+
+func (c *NamespacedCache) Len() int {
+	l := 0
+	for _, p := range c.partitions() {
+		l += p.Len()
+	}
+	return l
+}
+
+// SetLimit maxBytes 为总容量, maxEntries 只作用于默认命名空间, 0 表示不限制
+func (c *NamespacedCache) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxBytes, c.maxEntries = maxBytes, maxEntries
+	c.resize()
+}
+
+func (c *NamespacedCache) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	c.overhead = overhead
+	c.mu.Unlock()
+	for _, p := range c.partitions() {
+		p.SetOverhead(overhead)
+	}
+}
+
+func (c *NamespacedCache) EstimateOverhead() int64 {
+	return c.def.EstimateOverhead()
+}
+
+// Stats 汇总各命名空间的占用, 容量上限为总容量
+func (c *NamespacedCache) Stats() (stats Stats) {
+	for _, p := range c.partitions() {
+		st := p.Stats()
+		stats.Bytes += st.Bytes
+		stats.Entries += st.Entries
+		stats.Overhead = st.Overhead
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	stats.MaxBytes, stats.MaxEntries = c.maxBytes, c.maxEntries
+	return
+}
+
+func (c *NamespacedCache) Stop() {
+	for _, p := range c.partitions() {
+		p.Stop()
+	}
+}
+
+var (
+	_ CacheMemory = (*NamespacedCache)(nil)
+	_ CostSetter  = (*NamespacedCache)(nil)
+)
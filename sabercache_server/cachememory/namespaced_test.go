@@ -0,0 +1,61 @@
+package cachememory
+
+import (
+	"strconv"
+	"testing"
+)
+
+func newNamespacedLRU(maxBytes int64) *NamespacedCache {
+	return NewNamespacedCache(maxBytes, func(maxBytes int64) CacheMemory {
+		return NewLRUCache(maxBytes, nil)
+	})
+}
+
+func TestNamespaceKey(t *testing.T) {
+	if key := NamespaceKey("", "k"); key != "k" {
+		t.Fatalf("default namespace should not be prefixed, got %q", key)
+	}
+	if ns, key := SplitNamespace(NamespaceKey("team", "a:b")); ns != "team" || key != "a:b" {
+		t.Fatalf("split failed, got %q %q", ns, key)
+	}
+	if ns, key := SplitNamespace("a:b"); ns != "" || key != "a:b" {
+		t.Fatalf("split failed, got %q %q", ns, key)
+	}
+}
+
+// TestNamespaceQuota 命名空间只在自己的配额内淘汰, 不挤占其他命名空间
+func TestNamespaceQuota(t *testing.T) {
+	c := newNamespacedLRU(200)
+	defer c.Stop()
+	if err := c.SetQuota("bulk", 50); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.SetQuota("small", 200); err == nil {
+		t.Fatalf("quota over capacity should fail")
+	}
+	for i := 0; i < 10; i++ {
+		c.SetWithoutTTL("key"+strconv.Itoa(i), String("value"))
+	}
+	// bulk 批量写入只淘汰 bulk 自己的Key
+	for i := 0; i < 100; i++ {
+		c.SetWithoutTTL(NamespaceKey("bulk", "key"+strconv.Itoa(i)), String("value"))
+	}
+	stats := c.NamespaceStats()
+	if stats[""].Entries != 10 || stats["bulk"].Bytes > 50 || stats["bulk"].MaxBytes != 50 || stats[""].MaxBytes != 150 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if _, ok := c.Get(NamespaceKey("bulk", "key99")); !ok {
+		t.Fatalf("latest bulk key should be cached")
+	}
+	if st := c.Stats(); st.MaxBytes != 200 || st.Entries != stats[""].Entries+stats["bulk"].Entries {
+		t.Fatalf("unexpected total stats %+v", st)
+	}
+	// 总容量调低后按比例缩小
+	c.SetLimit(20, 0)
+	if st := c.Stats(); st.Bytes > 20 {
+		t.Fatalf("cache over capacity, %+v", st)
+	}
+	if !c.HasNamespace("bulk") || c.HasNamespace("other") || !c.HasNamespace("") {
+		t.Fatalf("has namespace failed")
+	}
+}
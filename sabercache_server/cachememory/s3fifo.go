@@ -0,0 +1,12 @@
+package cachememory
+
+import "sabercache_server/cachememory/generic"
+
+// S3FIFOCache 以 string 为Key、Value 为值的 generic.S3FIFOCache
+type S3FIFOCache = generic.S3FIFOCache[string, Value]
+
+func NewS3FIFOCache(maxBytes int64, callback OnEliminated) *S3FIFOCache {
+	return generic.NewS3FIFOCache[string, Value](maxBytes, callback)
+}
+
+var _ CacheMemory = (*S3FIFOCache)(nil)
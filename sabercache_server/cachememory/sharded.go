@@ -0,0 +1,181 @@
+package cachememory
+
+// ShardedCache 分片缓存
+// 按Key的哈希值将Key分散到多个相互独立的 CacheMemory 中, 每个分片持有各自的锁,
+// 从而降低多核下的锁竞争。总容量平均分配给各分片, 单个Key的大小不能超过分片容量。
+type ShardedCache struct {
+	shards []CacheMemory
+}
+
+// NewShardedCache newShard 根据分片容量创建单个分片, 可以是任意淘汰策略
+func NewShardedCache(shards int, maxBytes int64, newShard func(maxBytes int64) CacheMemory) *ShardedCache {
+	if shards < 1 {
+		shards = 1
+	}
+	c := &ShardedCache{shards: make([]CacheMemory, shards)}
+	for i := range c.shards {
+		c.shards[i] = newShard(shardLimit(maxBytes, i, shards))
+	}
+	return c
+}
+
+// shardLimit 第 i 个分片分到的容量上限。0 表示不限制, 因此上限小于分片数时每个分片至少分到1,
+// 总上限会略高于设置值, 但不会出现不受限制的分片
+func shardLimit(limit int64, i, shards int) int64 {
+	if limit <= 0 {
+		return 0
+	}
+	n := limit / int64(shards)
+	if int64(i) < limit%int64(shards) {
+		n++
+	}
+	if n == 0 {
+		n = 1
+	}
+	return n
+}
+
+func (c *ShardedCache) shard(key string) CacheMemory {
+	return c.shards[hashKey(key)%uint64(len(c.shards))]
+}
+
+func (c *ShardedCache) Get(key string) (Value, bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *ShardedCache) GetAll() (kv []*Entity) {
+	for _, s := range c.shards {
+		kv = append(kv, s.GetAll()...)
+	}
+	return
+}
+
+func (c *ShardedCache) SetWithoutTTL(key string, value Value) {
+	c.shard(key).SetWithoutTTL(key, value)
+}
+
+func (c *ShardedCache) SetWithTTL(key string, value Value, ttl int64) {
+	c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+func (c *ShardedCache) SetWithPTTL(key string, value Value, pttl int64) {
+	c.shard(key).SetWithPTTL(key, value, pttl)
+}
+
+func (c *ShardedCache) SetWithSlidingPTTL(key string, value Value, pttl int64) {
+	c.shard(key).SetWithSlidingPTTL(key, value, pttl)
+}
+
+// SetWithoutTTLCost 分片不支持代价感知时忽略 cost
+func (c *ShardedCache) SetWithoutTTLCost(key string, value Value, cost int64) {
+	s := c.shard(key)
+	if cs, ok := s.(CostSetter); ok {
+		cs.SetWithoutTTLCost(key, value, cost)
+		return
+	}
+	s.SetWithoutTTL(key, value)
+}
+
+// SetWithPTTLCost 分片不支持代价感知时忽略 cost
+func (c *ShardedCache) SetWithPTTLCost(key string, value Value, pttl int64, cost int64) {
+	s := c.shard(key)
+	if cs, ok := s.(CostSetter); ok {
+		cs.SetWithPTTLCost(key, value, pttl, cost)
+		return
+	}
+	s.SetWithPTTL(key, value, pttl)
+}
+
+// ExpireKeyMonitor 各分片在创建时已启动各自的过期监控, 此处无需处理
+func (c *ShardedCache) ExpireKeyMonitor() {}
+
+func (c *ShardedCache) MultiDeleteKey(keys []string, t int64) {
+	group := make(map[CacheMemory][]string)
+	for _, key := range keys {
+		s := c.shard(key)
+		group[s] = append(group[s], key)
+	}
+	for s, keys := range group {
+		s.MultiDeleteKey(keys, t)
+	}
+}
+
+func (c *ShardedCache) RemoveExpiredKey(key string) {
+	c.shard(key).RemoveExpiredKey(key)
+}
+
+func (c *ShardedCache) Delete(key string) bool {
+	return c.shard(key).Delete(key)
+}
+
+// Remove 从Key数量最多的分片淘汰一枚缓存
+func (c *ShardedCache) Remove() {
+	var target CacheMemory
+	maxLen := 0
+	for _, s := range c.shards {
+		if l := s.Len(); l > maxLen {
+			target, maxLen = s, l
+		}
+	}
+	if target != nil {
+		target.Remove()
+	}
+}
+
+func (c *ShardedCache) TTL(key string) int64 {
+	return c.shard(key).TTL(key)
+}
+
+func (c *ShardedCache) PTTL(key string) int64 {
+	return c.shard(key).PTTL(key)
+}
+
+func (c *ShardedCache) Len() int {
+	l := 0
+	for _, s := range c.shards {
+		l += s.Len()
+	}
+	return l
+}
+
+// SetLimit 容量上限平均分配给各分片, 0 表示不限制, 上限小于分片数时每个分片至少为1
+func (c *ShardedCache) SetLimit(maxBytes int64, maxEntries int) {
+	n := len(c.shards)
+	for i, s := range c.shards {
+		s.SetLimit(shardLimit(maxBytes, i, n), int(shardLimit(int64(maxEntries), i, n)))
+	}
+}
+
+func (c *ShardedCache) SetOverhead(overhead int64) {
+	for _, s := range c.shards {
+		s.SetOverhead(overhead)
+	}
+}
+
+func (c *ShardedCache) EstimateOverhead() int64 {
+	return c.shards[0].EstimateOverhead()
+}
+
+// Stats 汇总各分片的统计
+func (c *ShardedCache) Stats() (stats Stats) {
+	for _, s := range c.shards {
+		st := s.Stats()
+		stats.Bytes += st.Bytes
+		stats.Entries += st.Entries
+		stats.MaxBytes += st.MaxBytes
+		stats.MaxEntries += st.MaxEntries
+		stats.Overhead = st.Overhead
+	}
+	return
+}
+
+func (c *ShardedCache) Stop() {
+	for _, s := range c.shards {
+		s.Stop()
+	}
+}
+
+var (
+	_ CacheMemory = (*ShardedCache)(nil)
+	_ CostSetter  = (*ShardedCache)(nil)
+)
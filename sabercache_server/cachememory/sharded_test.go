@@ -0,0 +1,150 @@
+package cachememory
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func newShardedLRU(shards int, maxBytes int64) *ShardedCache {
+	return NewShardedCache(shards, maxBytes, func(maxBytes int64) CacheMemory {
+		return NewLRUCache(maxBytes, nil)
+	})
+}
+
+func TestShardedGet(t *testing.T) {
+	var cache CacheMemory = newShardedLRU(4, int64(1024))
+	defer cache.Stop()
+	for i := 0; i < 10; i++ {
+		cache.SetWithoutTTL("key"+strconv.Itoa(i), String("value"+strconv.Itoa(i)))
+	}
+	cache.SetWithTTL("ttl", String("1234"), 10)
+	for i := 0; i < 10; i++ {
+		if v, ok := cache.Get("key" + strconv.Itoa(i)); !ok || v.(String) != String("value"+strconv.Itoa(i)) {
+			t.Fatalf("cache hit key%d failed", i)
+		}
+	}
+	if ttl := cache.TTL("ttl"); ttl != 10 {
+		t.Fatalf("ttl test failed")
+	}
+	if l := cache.Len(); l != 11 {
+		t.Fatalf("len expect 11, got %d", l)
+	}
+	if kv := cache.GetAll(); len(kv) != 11 {
+		t.Fatalf("getall failed")
+	}
+	cache.MultiDeleteKey([]string{"key1", "key2", "ttl"}, 0)
+	if l := cache.Len(); l != 8 {
+		t.Fatalf("multiple delete failed")
+	}
+}
+
+func TestShardedCapacity(t *testing.T) {
+	total := int64(0)
+	c := NewShardedCache(3, int64(100), func(maxBytes int64) CacheMemory {
+		total += maxBytes
+		return NewLRUCache(maxBytes, nil)
+	})
+	defer c.Stop()
+	if total != 100 {
+		t.Fatalf("shard capacity sum expect 100, got %d", total)
+	}
+	for i := 0; i < 100; i++ {
+		c.SetWithoutTTL("key"+strconv.Itoa(i%10)+strconv.Itoa(i/10), String("value1"))
+	}
+	if l := c.Len(); l > 10 {
+		t.Fatalf("cache over capacity, len %d", l)
+	}
+}
+
+func TestShardedConcurrent(t *testing.T) {
+	var cache CacheMemory = newShardedLRU(8, int64(1<<20))
+	defer cache.Stop()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				key := strconv.Itoa(g) + "-" + strconv.Itoa(i)
+				cache.SetWithoutTTL(key, String("v"))
+				cache.Get(key)
+			}
+		}(g)
+	}
+	wg.Wait()
+	if l := cache.Len(); l != 8000 {
+		t.Fatalf("len expect 8000, got %d", l)
+	}
+}
+
+func benchmarkParallel(b *testing.B, cache CacheMemory) {
+	defer cache.Stop()
+	keys := make([]string, 1024)
+	for i := range keys {
+		keys[i] = "key" + strconv.Itoa(i)
+		cache.SetWithoutTTL(keys[i], String("value"))
+	}
+	var seed int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := int(atomic.AddInt64(&seed, 1) * 131)
+		for pb.Next() {
+			key := keys[i&1023]
+			if i&7 == 0 {
+				cache.SetWithoutTTL(key, String("value"))
+			} else {
+				cache.Get(key)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkLRUParallel(b *testing.B) {
+	benchmarkParallel(b, NewLRUCache(int64(1<<20), nil))
+}
+
+func BenchmarkShardedLRUParallel(b *testing.B) {
+	benchmarkParallel(b, newShardedLRU(32, int64(1<<20)))
+}
+
+func TestShardedLimit(t *testing.T) {
+	c := newShardedLRU(3, int64(0))
+	defer c.Stop()
+	c.SetLimit(100, 10)
+	stats := c.Stats()
+	if stats.MaxBytes != 100 || stats.MaxEntries != 10 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	for i := 0; i < 100; i++ {
+		c.SetWithoutTTL("key"+strconv.Itoa(i), String("v"))
+	}
+	if stats := c.Stats(); stats.Entries > 10 || stats.Bytes > 100 {
+		t.Fatalf("cache over limit %+v", stats)
+	}
+}
+
+// TestShardedSmallLimit 上限小于分片数时每个分片至少为1, 不会出现不受限制的分片
+func TestShardedSmallLimit(t *testing.T) {
+	c := NewShardedCache(16, 3, func(maxBytes int64) CacheMemory {
+		if maxBytes == 0 {
+			t.Fatalf("shard created without limit")
+		}
+		return NewLRUCache(maxBytes, nil)
+	})
+	defer c.Stop()
+	c.SetLimit(0, 3)
+	for i, s := range c.shards {
+		if st := s.Stats(); st.MaxEntries < 1 {
+			t.Fatalf("shard %d unlimited, %+v", i, st)
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		c.SetWithoutTTL("key"+strconv.Itoa(i), String("v"))
+	}
+	if l := c.Len(); l > 16 {
+		t.Fatalf("expect at most one entry per shard, got %d", l)
+	}
+}
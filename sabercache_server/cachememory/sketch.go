@@ -0,0 +1,75 @@
+package cachememory
+
+const (
+	sketchDepth   = 4
+	sketchMaxFreq = 15
+)
+
+var sketchSeeds = [sketchDepth]uint64{0xc3a5c85c97cb3127, 0xb492b66fbe98f273, 0x9ae16a3b2f90404f, 0xcbf29ce484222325}
+
+// countMinSketch 用于估计Key的访问频率, 每个计数器最大为15,
+// 累计记录 resetPeriod 次访问后所有计数器减半, 使频率随时间衰减。
+type countMinSketch struct {
+	table       [sketchDepth][]uint8
+	mask        uint64
+	additions   int64
+	resetPeriod int64
+}
+
+func newCountMinSketch(resetPeriod int64) *countMinSketch {
+	width := uint64(16)
+	for int64(width) < resetPeriod/10 {
+		width <<= 1
+	}
+	s := &countMinSketch{mask: width - 1, resetPeriod: resetPeriod}
+	for i := range s.table {
+		s.table[i] = make([]uint8, width)
+	}
+	return s
+}
+
+func (s *countMinSketch) index(h uint64, i int) uint64 {
+	h = (h ^ sketchSeeds[i]) * 0x9e3779b97f4a7c15
+	return (h ^ (h >> 32)) & s.mask
+}
+
+// Increment 记录一次Key访问
+func (s *countMinSketch) Increment(key string) {
+	h := hashKey(key)
+	added := false
+	for i := range s.table {
+		idx := s.index(h, i)
+		if s.table[i][idx] < sketchMaxFreq {
+			s.table[i][idx]++
+			added = true
+		}
+	}
+	if added {
+		s.additions++
+		if s.additions >= s.resetPeriod {
+			s.reset()
+		}
+	}
+}
+
+// Estimate 估计Key的访问频率
+func (s *countMinSketch) Estimate(key string) uint8 {
+	h := hashKey(key)
+	freq := uint8(sketchMaxFreq)
+	for i := range s.table {
+		if v := s.table[i][s.index(h, i)]; v < freq {
+			freq = v
+		}
+	}
+	return freq
+}
+
+// reset 所有计数器减半
+func (s *countMinSketch) reset() {
+	for i := range s.table {
+		for j := range s.table[i] {
+			s.table[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
@@ -0,0 +1,23 @@
+package cachememory
+
+import (
+	"sabercache_server/cachememory/generic"
+	"time"
+)
+
+// 时间轮实现位于 generic 包, 此处以 string 为Key实例化供 ARC/W-TinyLFU 使用
+
+// DefaultTick 时间轮默认精度
+const DefaultTick = generic.DefaultTick
+
+// Clock 时间源, 测试时可替换
+type Clock = generic.Clock
+
+// SystemClock 系统时间
+var SystemClock = generic.SystemClock
+
+type TimingWheel = generic.TimingWheel[string]
+
+func NewTimingWheel(tick time.Duration, clock Clock) *TimingWheel {
+	return generic.NewTimingWheel[string](tick, clock)
+}
@@ -0,0 +1,415 @@
+package cachememory
+
+import (
+	"container/list"
+	"sabercache_server/cachememory/generic"
+	"sync"
+	"time"
+	"unsafe"
+)
+
+// DefaultTinyLFUResetPeriod 默认频率衰减周期
+const DefaultTinyLFUResetPeriod int64 = 10000
+
+const (
+	tinyLFUWindowPercent    = 1  // 窗口LRU占总容量的百分比
+	tinyLFUProtectedPercent = 80 // 保护区占主空间的百分比
+)
+
+const (
+	segmentWindow = iota
+	segmentProbation
+	segmentProtected
+)
+
+// TinyLFUCache W-TinyLFU缓存
+// 新Key先进入窗口LRU, 被挤出窗口后进入主空间(分段LRU)的试用区,
+// 超出容量时由 count-min sketch 估计的访问频率决定淘汰候选Key还是试用区末尾的Key。
+// 试用区的Key再次被访问后晋升到保护区。容量按 len(key)+Value.Len() 以字节计。
+type TinyLFUCache struct {
+	capacity     int64 // Cache 最大容量(Byte), 0 表示不限制
+	maxEntries   int   // Key 数量上限, 0 表示不限制
+	overhead     int64 // 每个Key额外计入的字节数
+	length       int64 // Cache 当前容量(Byte)
+	windowCap    int64
+	protectedCap int64
+	windowMax    int // 窗口Key数量上限
+	protectedMax int // 保护区Key数量上限
+	windowLen    int64
+	protectedLen int64
+	window       *list.List // 链头表示最近使用
+	probation    *list.List
+	protected    *list.List
+	hashmap      map[string]*list.Element
+	wheel        *TimingWheel
+	sketch       *countMinSketch
+	mu           sync.Mutex
+	stop         chan struct{}
+	stopOnce     sync.Once
+	callback     OnEliminated
+}
+
+type tinyLFUEntry struct {
+	entity  *Entity
+	segment int
+}
+
+// NewTinyLFUCache resetPeriod 为频率统计的衰减周期(访问次数), 小于等于0时取默认值
+func NewTinyLFUCache(maxBytes int64, resetPeriod int64, callback OnEliminated) *TinyLFUCache {
+	if resetPeriod <= 0 {
+		resetPeriod = DefaultTinyLFUResetPeriod
+	}
+	c := &TinyLFUCache{
+		capacity:  maxBytes,
+		window:    list.New(),
+		probation: list.New(),
+		protected: list.New(),
+		hashmap:   make(map[string]*list.Element),
+		wheel:     NewTimingWheel(DefaultTick, SystemClock),
+		sketch:    newCountMinSketch(resetPeriod),
+		callback:  callback,
+		stop:      make(chan struct{}),
+	}
+	c.resize()
+	go c.ExpireKeyMonitor()
+	return c
+}
+
+func (c *TinyLFUCache) Get(key string) (Value, bool) {
+	c.mu.Lock()
+	c.sketch.Increment(key)
+	if elem, ok := c.hashmap[key]; ok {
+		entity := elem.Value.(*tinyLFUEntry).entity
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			c.mu.Unlock()
+			c.RemoveExpiredKey(key)
+			return nil, false
+		}
+		slideExpire(entity)
+		c.onAccess(elem)
+		c.mu.Unlock()
+		return entity.Value, true
+	}
+	c.mu.Unlock()
+	return nil, false
+}
+
+func (c *TinyLFUCache) GetAll() (kv []*Entity) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, elem := range c.hashmap {
+		entity := elem.Value.(*tinyLFUEntry).entity
+		if entity.ExpiredTime != -1 && entity.ExpiredTime <= time.Now().UnixMilli() {
+			continue
+		}
+		kv = append(kv, entity)
+	}
+	return
+}
+
+func (c *TinyLFUCache) SetWithoutTTL(key string, value Value) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.set(key, value, -1, 0) {
+		c.wheel.Remove(key)
+	}
+}
+
+func (c *TinyLFUCache) SetWithTTL(key string, value Value, ttl int64) {
+	c.SetWithPTTL(key, value, ttl*1000)
+}
+
+// SetWithPTTL 写入Key并设置毫秒级过期时间
+func (c *TinyLFUCache) SetWithPTTL(key string, value Value, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, 0) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// resize 按容量上限计算窗口与保护区的大小
+func (c *TinyLFUCache) resize() {
+	c.windowCap = c.capacity * tinyLFUWindowPercent / 100
+	if c.windowCap < 1 {
+		c.windowCap = 1
+	}
+	c.protectedCap = (c.capacity - c.windowCap) * tinyLFUProtectedPercent / 100
+	c.windowMax = c.maxEntries * tinyLFUWindowPercent / 100
+	if c.windowMax < 1 {
+		c.windowMax = 1
+	}
+	c.protectedMax = (c.maxEntries - c.windowMax) * tinyLFUProtectedPercent / 100
+}
+
+// SetWithSlidingPTTL 写入Key并设置滑动过期时间, 每次 Get 命中都会将到期时间顺延 pttl 毫秒
+func (c *TinyLFUCache) SetWithSlidingPTTL(key string, value Value, pttl int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	expireTime := time.Now().UnixMilli() + pttl
+	if c.set(key, value, expireTime, pttl) {
+		c.wheel.Add(key, time.UnixMilli(expireTime))
+	}
+}
+
+// set 写入Key, 返回是否写入成功
+func (c *TinyLFUCache) set(key string, value Value, expireTime, sliding int64) bool {
+	kvSize := int64(len(key)) + int64(value.Len()) + c.overhead
+	if c.capacity > 0 && kvSize > c.capacity {
+		return false
+	}
+	c.sketch.Increment(key)
+	if elem, ok := c.hashmap[key]; ok {
+		// 更新缓存Key值
+		entry := elem.Value.(*tinyLFUEntry)
+		delta := int64(value.Len()) - int64(entry.entity.Value.Len())
+		c.length += delta
+		switch entry.segment {
+		case segmentWindow:
+			c.windowLen += delta
+		case segmentProtected:
+			c.protectedLen += delta
+		}
+		old := entry.entity.Value
+		entry.entity.Value = value
+		entry.entity.ExpiredTime, entry.entity.Sliding = expireTime, sliding
+		if c.callback != nil {
+			c.callback(key, old, EvictReplaced)
+		}
+		c.onAccess(elem)
+		c.evict(elem)
+		_, ok := c.hashmap[key]
+		return ok
+	}
+	// 新增缓存Key
+	entry := &tinyLFUEntry{entity: &Entity{Key: key, Value: value, ExpiredTime: expireTime, Sliding: sliding}, segment: segmentWindow}
+	elem := c.window.PushFront(entry)
+	c.hashmap[key] = elem
+	c.windowLen += kvSize
+	c.length += kvSize
+	c.evict(elem)
+	_, ok := c.hashmap[key]
+	return ok
+}
+
+// onAccess 命中后调整Key所在分段
+func (c *TinyLFUCache) onAccess(elem *list.Element) {
+	entry := elem.Value.(*tinyLFUEntry)
+	switch entry.segment {
+	case segmentWindow:
+		c.window.MoveToFront(elem)
+	case segmentProbation:
+		// 试用区Key再次访问, 晋升到保护区
+		c.probation.Remove(elem)
+		entry.segment = segmentProtected
+		c.hashmap[entry.entity.Key] = c.protected.PushFront(entry)
+		c.protectedLen += c.entitySize(entry.entity)
+		for c.protected.Len() > 1 && ((c.capacity > 0 && c.protectedLen > c.protectedCap) ||
+			(c.maxEntries > 0 && c.protected.Len() > c.protectedMax)) {
+			// 保护区超出容量, 末尾Key降级到试用区
+			back := c.protected.Back()
+			demoted := back.Value.(*tinyLFUEntry)
+			c.protected.Remove(back)
+			c.protectedLen -= c.entitySize(demoted.entity)
+			demoted.segment = segmentProbation
+			c.hashmap[demoted.entity.Key] = c.probation.PushFront(demoted)
+		}
+	case segmentProtected:
+		c.protected.MoveToFront(elem)
+	}
+}
+
+// evict 将超出窗口容量的Key移入试用区, 再按频率淘汰直至不超过总容量
+// keep 为本次写入的Key, 在窗口中时不会被移出窗口
+func (c *TinyLFUCache) evict(keep *list.Element) {
+	var candidates []*list.Element
+	for c.window.Len() > 1 && ((c.capacity > 0 && c.windowLen > c.windowCap) ||
+		(c.maxEntries > 0 && c.window.Len() > c.windowMax)) {
+		back := c.window.Back()
+		if back == keep {
+			break
+		}
+		entry := back.Value.(*tinyLFUEntry)
+		c.window.Remove(back)
+		c.windowLen -= c.entitySize(entry.entity)
+		entry.segment = segmentProbation
+		elem := c.probation.PushFront(entry)
+		c.hashmap[entry.entity.Key] = elem
+		candidates = append(candidates, elem)
+	}
+	for (c.capacity > 0 && c.length > c.capacity) || (c.maxEntries > 0 && len(c.hashmap) > c.maxEntries) {
+		victim := c.probation.Back()
+		if victim == nil {
+			victim = c.protected.Back()
+		}
+		if victim == nil {
+			victim = c.window.Back()
+		}
+		if len(candidates) == 0 {
+			c.removeElement(victim, EvictCapacity)
+			continue
+		}
+		candidate := candidates[0]
+		if candidate == victim {
+			candidates = candidates[1:]
+			c.removeElement(victim, EvictCapacity)
+			continue
+		}
+		candidateKey := candidate.Value.(*tinyLFUEntry).entity.Key
+		victimKey := victim.Value.(*tinyLFUEntry).entity.Key
+		if c.sketch.Estimate(candidateKey) > c.sketch.Estimate(victimKey) {
+			c.removeElement(victim, EvictCapacity)
+		} else {
+			candidates = candidates[1:]
+			c.removeElement(candidate, EvictCapacity)
+		}
+	}
+}
+
+func (c *TinyLFUCache) removeElement(elem *list.Element, reason EvictReason) {
+	entry := elem.Value.(*tinyLFUEntry)
+	size := c.entitySize(entry.entity)
+	switch entry.segment {
+	case segmentWindow:
+		c.window.Remove(elem)
+		c.windowLen -= size
+	case segmentProbation:
+		c.probation.Remove(elem)
+	case segmentProtected:
+		c.protected.Remove(elem)
+		c.protectedLen -= size
+	}
+	delete(c.hashmap, entry.entity.Key)
+	c.wheel.Remove(entry.entity.Key)
+	c.length -= size
+	// 移除后的善后处理
+	if c.callback != nil {
+		c.callback(entry.entity.Key, entry.entity.Value, reason)
+	}
+}
+
+// ExpireKeyMonitor 定期推进时间轮, 移除到期Key
+func (c *TinyLFUCache) ExpireKeyMonitor() {
+	c.wheel.Run(&c.mu, c.stop, c.expireKey)
+}
+
+func (c *TinyLFUCache) RemoveExpiredKey(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.removeKey(key, EvictExpired)
+}
+
+// Delete 删除Key, 返回Key是否存在
+func (c *TinyLFUCache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.removeKey(key, EvictDeleted)
+}
+
+// MultiDeleteKey 批量移除Key, t 为Key的到期时间, 仅用于兼容旧接口
+func (c *TinyLFUCache) MultiDeleteKey(keys []string, t int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, v := range keys {
+		c.removeKey(v, EvictExpired)
+	}
+}
+
+func (c *TinyLFUCache) removeKey(key string, reason EvictReason) bool {
+	if elem, ok := c.hashmap[key]; ok {
+		c.removeElement(elem, reason)
+		return true
+	}
+	return false
+}
+
+// expireKey 时间轮到期回调, 滑动过期的Key期间被访问过时按新的到期时间重新计时
+func (c *TinyLFUCache) expireKey(key string) {
+	if elem, ok := c.hashmap[key]; ok && rearmExpire(c.wheel, elem.Value.(*tinyLFUEntry).entity) {
+		return
+	}
+	c.removeKey(key, EvictExpired)
+}
+
+// Remove 淘汰一枚缓存, 依次选择试用区、保护区、窗口的末尾
+func (c *TinyLFUCache) Remove() {
+	victim := c.probation.Back()
+	if victim == nil {
+		victim = c.protected.Back()
+	}
+	if victim == nil {
+		victim = c.window.Back()
+	}
+	if victim != nil {
+		c.removeElement(victim, EvictCapacity)
+	}
+}
+
+func (c *TinyLFUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.hashmap)
+}
+
+// SetLimit 调整容量上限, 0 表示不限制, 超出部分立即淘汰
+func (c *TinyLFUCache) SetLimit(maxBytes int64, maxEntries int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.capacity, c.maxEntries = maxBytes, maxEntries
+	c.resize()
+	c.evict(nil)
+}
+
+// SetOverhead 设置每个Key额外计入的字节数, 超出容量的部分立即淘汰
+func (c *TinyLFUCache) SetOverhead(overhead int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delta := overhead - c.overhead
+	c.length += delta * int64(len(c.hashmap))
+	c.windowLen += delta * int64(c.window.Len())
+	c.protectedLen += delta * int64(c.protected.Len())
+	c.overhead = overhead
+	c.evict(nil)
+}
+
+// EstimateOverhead 估算每个Key在链表节点、tinyLFUEntry、Entity 与 map 中的额外开销, 不含 sketch
+func (c *TinyLFUCache) EstimateOverhead() int64 {
+	return generic.EntryOverhead[string, Value](unsafe.Sizeof(tinyLFUEntry{}))
+}
+
+func (c *TinyLFUCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Stats{Bytes: c.length, Entries: len(c.hashmap), MaxBytes: c.capacity, MaxEntries: c.maxEntries, Overhead: c.overhead}
+}
+
+func (c *TinyLFUCache) TTL(key string) int64 {
+	return pttlToTTL(c.PTTL(key))
+}
+
+// PTTL 返回Key剩余的毫秒数, -1 表示永不过期, -2 表示Key不存在
+func (c *TinyLFUCache) PTTL(key string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.hashmap[key]; ok {
+		return remainingPTTL(elem.Value.(*tinyLFUEntry).entity.ExpiredTime)
+	}
+	return -2
+}
+
+func (c *TinyLFUCache) Close() {
+	c.stopOnce.Do(func() {
+		close(c.stop)
+	})
+}
+
+func (c *TinyLFUCache) Stop() {
+	c.Close()
+}
+
+func (c *TinyLFUCache) entitySize(entity *Entity) int64 {
+	return int64(len(entity.Key)) + int64(entity.Value.Len()) + c.overhead
+}
+
+var _ CacheMemory = (*TinyLFUCache)(nil)
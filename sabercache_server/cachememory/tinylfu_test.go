@@ -0,0 +1,110 @@
+package cachememory
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestTinyLFUGet(t *testing.T) {
+	var cache CacheMemory = NewTinyLFUCache(int64(1024), 0, nil)
+	defer cache.Stop()
+	t.Run("SetWithoutTTL", func(t *testing.T) {
+		cache.SetWithoutTTL("key1", String("1234"))
+		if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+			t.Fatalf("cache hit key1=1234 failed")
+		}
+	})
+	t.Run("SetWithTTL", func(t *testing.T) {
+		cache.SetWithTTL("key1", String("1234"), 10)
+		if v, ok := cache.Get("key1"); !ok || v.(String) != "1234" {
+			t.Fatalf("cache hit key1=1234 failed")
+		}
+	})
+	t.Run("GetNilKey", func(t *testing.T) {
+		if _, ok := cache.Get("key2"); ok {
+			t.Fatalf("cache miss key2 failed")
+		}
+	})
+}
+
+func TestTinyLFUTTL(t *testing.T) {
+	var cache CacheMemory = NewTinyLFUCache(int64(1024), 0, nil)
+	defer cache.Stop()
+	cache.SetWithTTL("key1", String("1234"), 5)
+	if ttl := cache.TTL("key1"); ttl != 5 {
+		t.Fatalf("ttl test key1 failed")
+	}
+	cache.SetWithTTL("key1", String("1234"), 2)
+	time.Sleep(2 * time.Second)
+	if _, ok := cache.Get("key1"); ok {
+		t.Fatalf("cache remove expired Key key1 failed")
+	}
+}
+
+func TestTinyLFUAdmission(t *testing.T) {
+	// 每个Key占用10字节, 最多容纳10个Key
+	var cache CacheMemory = NewTinyLFUCache(int64(100), 0, nil)
+	defer cache.Stop()
+	for i := 0; i < 10; i++ {
+		cache.SetWithoutTTL("hot"+strconv.Itoa(i), String("value1"))
+	}
+	for n := 0; n < 3; n++ {
+		for i := 0; i < 10; i++ {
+			cache.Get("hot" + strconv.Itoa(i))
+		}
+	}
+	// 一次性扫描的冷Key频率低, 不应挤掉热点Key
+	for i := 0; i < 100; i++ {
+		cache.SetWithoutTTL("c"+strconv.Itoa(i%10)+strconv.Itoa(i/10)+"x", String("value1"))
+	}
+	hits := 0
+	for i := 0; i < 10; i++ {
+		if _, ok := cache.Get("hot" + strconv.Itoa(i)); ok {
+			hits++
+		}
+	}
+	if hits < 8 {
+		t.Fatalf("hot keys evicted by scan, hits %d", hits)
+	}
+	if l := cache.Len(); l > 10 {
+		t.Fatalf("cache over capacity, len %d", l)
+	}
+}
+
+func TestTinyLFUMaxSize(t *testing.T) {
+	var cache CacheMemory = NewTinyLFUCache(int64(4), 0, nil)
+	defer cache.Stop()
+	cache.SetWithoutTTL("key1", String("value1"))
+	if _, ok := cache.Get("key1"); ok {
+		t.Fatalf("Get out of size Key key1")
+	}
+	cache.SetWithoutTTL("k2", String("v2"))
+	if _, ok := cache.Get("k2"); !ok {
+		t.Fatalf("Get k2 fialed")
+	}
+	cache.SetWithoutTTL("k2", String("value2"))
+	if v2, ok := cache.Get("k2"); !ok || v2 != String("v2") {
+		t.Fatalf("Update out of size Key k2")
+	}
+}
+
+func TestCountMinSketch(t *testing.T) {
+	s := newCountMinSketch(100)
+	for i := 0; i < 5; i++ {
+		s.Increment("key1")
+	}
+	if f := s.Estimate("key1"); f != 5 {
+		t.Fatalf("estimate key1 expect 5, got %d", f)
+	}
+	for i := 0; i < 20; i++ {
+		s.Increment("key2")
+	}
+	if f := s.Estimate("key2"); f != sketchMaxFreq {
+		t.Fatalf("estimate key2 expect %d, got %d", sketchMaxFreq, f)
+	}
+	s.reset()
+	if f := s.Estimate("key1"); f != 2 {
+		t.Fatalf("reset key1 expect 2, got %d", f)
+	}
+}
@@ -0,0 +1,143 @@
+package cachememory
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// testdata/*.trace 每行为一次请求 "key size", size 为 Value 的字节数, # 开头的行为注释;
+// zipf.trace 为 zipf 分布的访问中每隔一段穿插一次性扫描
+var traces = []string{"zipf"}
+
+type request struct {
+	key  string
+	size int
+}
+
+// sized 只记录大小的 Value
+type sized int
+
+func (s sized) Len() int {
+	return int(s)
+}
+
+// loadTrace 读取请求序列, 并返回所有Key的总字节数
+func loadTrace(tb testing.TB, name string) (reqs []request, total int64) {
+	file, err := os.Open("testdata/" + name + ".trace")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	defer file.Close()
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		size, err := strconv.Atoi(fields[1])
+		if err != nil {
+			tb.Fatal(err)
+		}
+		reqs = append(reqs, request{key: fields[0], size: size})
+		if !seen[fields[0]] {
+			seen[fields[0]] = true
+			total += int64(len(fields[0]) + size)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		tb.Fatal(err)
+	}
+	return
+}
+
+var tracePolicies = map[string]func(maxBytes int64) CacheMemory{
+	"lru":    func(maxBytes int64) CacheMemory { return NewLRUCache(maxBytes, nil) },
+	"lfu":    func(maxBytes int64) CacheMemory { return NewLFUCache(maxBytes, nil) },
+	"fifo":   func(maxBytes int64) CacheMemory { return NewFIFOCache(maxBytes, nil) },
+	"clock":  func(maxBytes int64) CacheMemory { return NewClockCache(maxBytes, nil) },
+	"s3fifo": func(maxBytes int64) CacheMemory { return NewS3FIFOCache(maxBytes, nil) },
+}
+
+// replay 按顺序回放请求, 未命中时写入缓存, 返回命中率
+func replay(cache CacheMemory, reqs []request) float64 {
+	hits := 0
+	for _, req := range reqs {
+		if _, ok := cache.Get(req.key); ok {
+			hits++
+		} else {
+			cache.SetWithoutTTL(req.key, sized(req.size))
+		}
+	}
+	return float64(hits) / float64(len(reqs))
+}
+
+// TestTraceHitRatio 容量为总字节数的10%时, CLOCK 与 S3-FIFO 的命中率不低于 FIFO
+func TestTraceHitRatio(t *testing.T) {
+	for _, name := range traces {
+		reqs, total := loadTrace(t, name)
+		ratio := make(map[string]float64)
+		for policy, newCache := range tracePolicies {
+			cache := newCache(total / 10)
+			ratio[policy] = replay(cache, reqs)
+			cache.Stop()
+		}
+		t.Logf("%s: %v", name, ratio)
+		if ratio["clock"] < ratio["fifo"] || ratio["s3fifo"] < ratio["fifo"] {
+			t.Fatalf("%s: unexpected hit ratio %v", name, ratio)
+		}
+	}
+}
+
+// BenchmarkHitRatio 单线程回放, hit% 为命中率
+func BenchmarkHitRatio(b *testing.B) {
+	for _, name := range traces {
+		reqs, total := loadTrace(b, name)
+		for _, policy := range []string{"lru", "lfu", "fifo", "clock", "s3fifo"} {
+			b.Run(name+"/"+policy, func(b *testing.B) {
+				cache := tracePolicies[policy](total / 10)
+				defer cache.Stop()
+				hits := 0
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					req := reqs[i%len(reqs)]
+					if _, ok := cache.Get(req.key); ok {
+						hits++
+					} else {
+						cache.SetWithoutTTL(req.key, sized(req.size))
+					}
+				}
+				b.ReportMetric(float64(hits)*100/float64(b.N), "hit%")
+			})
+		}
+	}
+}
+
+// BenchmarkThroughput 并发回放, 命中路径不移动链表节点的策略在读多时锁竞争更小
+func BenchmarkThroughput(b *testing.B) {
+	for _, name := range traces {
+		reqs, total := loadTrace(b, name)
+		for _, policy := range []string{"lru", "lfu", "fifo", "clock", "s3fifo"} {
+			b.Run(name+"/"+policy, func(b *testing.B) {
+				cache := tracePolicies[policy](total / 10)
+				defer cache.Stop()
+				replay(cache, reqs)
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					i := 0
+					for pb.Next() {
+						req := reqs[i%len(reqs)]
+						if _, ok := cache.Get(req.key); !ok {
+							cache.SetWithoutTTL(req.key, sized(req.size))
+						}
+						i++
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package sabercache_server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// DefaultGroup 请求未指定缓存组时使用的组, NewSaberCache 创建的实例注册为该组
+const DefaultGroup = "default"
+
+var (
+	groupsMu sync.RWMutex
+	groups   = make(map[string]*SaberCache)
+)
+
+// groupName 缓存组名称只能包含字母、数字、下划线与中划线, 名称会用于备份文件名
+var groupName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// NewGroup 创建并注册名为 name 的缓存组, 每个组有独立的容量、淘汰策略、Retriever 与备份文件;
+// 名称不合法或同名的组已存在时 panic
+func NewGroup(name string, maxBytes int64, strategy string, retriever Retriever, opts ...Option) *SaberCache {
+	if name == "" {
+		name = DefaultGroup
+	}
+	if !groupName.MatchString(name) {
+		panic(fmt.Sprintf("invalid group name %q", name))
+	}
+	groupsMu.Lock()
+	defer groupsMu.Unlock()
+	if _, ok := groups[name]; ok {
+		panic(fmt.Sprintf("duplicate registration of group %s", name))
+	}
+	sc := newSaberCache(name, maxBytes, strategy, retriever, opts...)
+	groups[name] = sc
+	return sc
+}
+
+// replaceGroup 在持有锁时用 sc 替换同名的组, 解锁后 Close 原有实例
+func replaceGroup(name string, sc *SaberCache) {
+	groupsMu.Lock()
+	old := groups[name]
+	groups[name] = sc
+	groupsMu.Unlock()
+	if old == nil {
+		return
+	}
+	if err := old.Close(context.Background()); err != nil {
+		log.Printf("close group %s failed: %v", name, err)
+	}
+}
+
+// RemoveGroup 注销名为 name 的缓存组并 Close, 组不存在时什么也不做
+func RemoveGroup(ctx context.Context, name string) error {
+	if name == "" {
+		name = DefaultGroup
+	}
+	groupsMu.Lock()
+	sc, ok := groups[name]
+	delete(groups, name)
+	groupsMu.Unlock()
+	if !ok {
+		return nil
+	}
+	return sc.Close(ctx)
+}
+
+// GetGroup 返回名为 name 的缓存组, name 为空时返回默认组, 不存在时返回 nil
+func GetGroup(name string) *SaberCache {
+	if name == "" {
+		name = DefaultGroup
+	}
+	groupsMu.RLock()
+	defer groupsMu.RUnlock()
+	return groups[name]
+}
+
+// Groups 返回已注册的缓存组名称
+func Groups() []string {
+	groupsMu.RLock()
+	defer groupsMu.RUnlock()
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// backupFile 缓存组的备份文件名, 默认组沿用 backup.txt
+func backupFile(name string) string {
+	if name == DefaultGroup {
+		return "backup.txt"
+	}
+	return "backup_" + name + ".txt"
+}
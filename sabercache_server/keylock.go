@@ -0,0 +1,55 @@
+package sabercache_server
+
+import "sync"
+
+// keyStripes Key锁的分段数
+const keyStripes = 256
+
+// keyLocks 按Key哈希分段的锁与版本号。Set/Delete 持有锁写入数据源、更新缓存并将版本号加一;
+// 回源前记下版本号, 回源结束后版本号未变化才写入结果, 以免覆盖或删除回源期间写入的值。
+// 不同的Key可能落在同一分段, 此时只是多放弃一次回源结果, 不影响正确性
+type keyLocks [keyStripes]keyStripe
+
+type keyStripe struct {
+	mu      sync.Mutex
+	version uint64
+}
+
+// stripe FNV-1a 哈希选择分段
+func (l *keyLocks) stripe(key string) *keyStripe {
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return &l[h%keyStripes]
+}
+
+// version 返回Key所在分段当前的版本号
+func (l *keyLocks) version(key string) uint64 {
+	s := l.stripe(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.version
+}
+
+// write 持有Key所在分段的锁执行 fn, 并将版本号加一
+func (l *keyLocks) write(key string, fn func()) {
+	s := l.stripe(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.version++
+	fn()
+}
+
+// ifUnchanged Key所在分段的版本号仍为 version 时持有锁执行 fn, 返回 fn 是否被执行
+func (l *keyLocks) ifUnchanged(key string, version uint64, fn func()) bool {
+	s := l.stripe(key)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.version != version {
+		return false
+	}
+	fn()
+	return true
+}
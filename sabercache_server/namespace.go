@@ -0,0 +1,80 @@
+package sabercache_server
+
+import (
+	"context"
+	"fmt"
+	"sabercache_server/cachememory"
+)
+
+type namespaceKey struct{}
+
+// NamespaceFromContext 返回回源或写入数据源的Key所属的命名空间, 默认命名空间为空字符串。
+// 调用 Retriever 与 Writer 时传入的Key不带命名空间前缀
+func NamespaceFromContext(ctx context.Context) string {
+	ns, _ := ctx.Value(namespaceKey{}).(string)
+	return ns
+}
+
+// withNamespace 拆分存储Key, 将命名空间放入 ctx
+func withNamespace(ctx context.Context, key string) (context.Context, string) {
+	ns, rawKey := cachememory.SplitNamespace(key)
+	if ns == "" {
+		return ctx, rawKey
+	}
+	return context.WithValue(ctx, namespaceKey{}, ns), rawKey
+}
+
+// WithNamespaces 创建命名空间, quotas 为各命名空间的字节配额; 配额从缓存组的容量中划出,
+// 各命名空间只在自己的配额内淘汰, 配额之和需小于缓存组的容量, 否则 panic
+func WithNamespaces(quotas map[string]int64) Option {
+	return func(sc *SaberCache) {
+		for ns, quota := range quotas {
+			if err := sc.cache.SetNamespaceQuota(ns, quota); err != nil {
+				panic(fmt.Sprintf("group %s: %v", sc.name, err))
+			}
+		}
+	}
+}
+
+// SetNamespaceQuota 设置命名空间的字节配额, 命名空间不存在时创建
+func (sc *SaberCache) SetNamespaceQuota(ns string, quota int64) error {
+	return sc.cache.SetNamespaceQuota(ns, quota)
+}
+
+// HasNamespace 默认命名空间(空字符串)始终存在
+func (sc *SaberCache) HasNamespace(ns string) bool {
+	return sc.cache.HasNamespace(ns)
+}
+
+// NamespaceStats 返回各命名空间的占用、容量上限与淘汰次数, 默认命名空间的键为空字符串
+func (sc *SaberCache) NamespaceStats() map[string]NamespaceStats {
+	return sc.cache.NamespaceStats()
+}
+
+// retrieveBatch 按命名空间分别调用 RetrieveBatch, 返回结果的Key为存储Key
+func retrieveBatch(ctx context.Context, br BatchRetriever, keys []string) (map[string][]byte, error) {
+	groups := make(map[string][]string)
+	for _, key := range keys {
+		ns, _ := cachememory.SplitNamespace(key)
+		groups[ns] = append(groups[ns], key)
+	}
+	values := make(map[string][]byte, len(keys))
+	for ns, keys := range groups {
+		rawKeys := make([]string, len(keys))
+		for i, key := range keys {
+			_, rawKeys[i] = cachememory.SplitNamespace(key)
+		}
+		nsCtx := ctx
+		if ns != "" {
+			nsCtx = context.WithValue(ctx, namespaceKey{}, ns)
+		}
+		batch, err := br.RetrieveBatch(nsCtx, rawKeys)
+		if err != nil {
+			return nil, err
+		}
+		for rawKey, value := range batch {
+			values[cachememory.NamespaceKey(ns, rawKey)] = value
+		}
+	}
+	return values, nil
+}
@@ -1,45 +1,190 @@
 package sabercache_server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
 	"sabercache_server/cachememory"
 	"sabercache_server/singleflight"
+	"sync"
+	"sync/atomic"
+	"time"
 )
 
-var sabercache *SaberCache
+// ErrNotFound Retriever 确认数据不存在时返回的错误(可用 %w 包装), 该结果会被负缓存;
+// 其他错误视为暂时性错误, 不会被缓存
+var ErrNotFound = errors.New("key not found")
 
+// Retriever 缓存未命中时从数据源取回数据, ctx 携带调用方的值与 SaberCache 的回源超时, 实现应在 ctx 结束时尽快返回
 type Retriever interface {
-	retrieve(string) ([]byte, error)
+	Retrieve(ctx context.Context, key string) ([]byte, error)
 }
+
+// RetrieverFunc 不关心 ctx 的 Retriever
 type RetrieverFunc func(key string) ([]byte, error)
 
-func (f RetrieverFunc) retrieve(key string) ([]byte, error) {
+func (f RetrieverFunc) Retrieve(ctx context.Context, key string) ([]byte, error) {
 	return f(key)
 }
 
+type ContextRetrieverFunc func(ctx context.Context, key string) ([]byte, error)
+
+func (f ContextRetrieverFunc) Retrieve(ctx context.Context, key string) ([]byte, error) {
+	return f(ctx, key)
+}
+
+// NoExpiry 作为TTL时表示取回的数据永不过期
+const NoExpiry time.Duration = -1
+
+// DefaultLoadTTL 未通过 WithLoadTTL 配置时, 取回数据的默认过期时间
+const DefaultLoadTTL = time.Minute
+
+// DefaultLoadTimeout 未通过 WithLoadTimeout 配置时, 一次回源(含重试)的超时时间
+const DefaultLoadTimeout = 10 * time.Second
+
+// BatchRetriever 一次取回多个Key, 返回结果中缺少的Key视为数据源中不存在;
+// Retriever 同时实现该接口时, GetMulti 将未命中的Key合并为一次回源
+type BatchRetriever interface {
+	Retriever
+	RetrieveBatch(ctx context.Context, keys []string) (map[string][]byte, error)
+}
+
+// TTLRetriever 取回数据的同时返回该Key的过期时间, ttl 为0时使用 SaberCache 的默认TTL, NoExpiry 表示永不过期
+type TTLRetriever interface {
+	Retriever
+	RetrieveWithTTL(ctx context.Context, key string) ([]byte, time.Duration, error)
+}
+
+type RetrieverWithTTLFunc func(ctx context.Context, key string) ([]byte, time.Duration, error)
+
+func (f RetrieverWithTTLFunc) Retrieve(ctx context.Context, key string) ([]byte, error) {
+	bytes, _, err := f(ctx, key)
+	return bytes, err
+}
+
+func (f RetrieverWithTTLFunc) RetrieveWithTTL(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	return f(ctx, key)
+}
+
 type SaberCache struct {
-	cache     *Cache
-	server    *Server
-	retriever Retriever
-	flight    *singleflight.Flight
+	name         string
+	cache        *Cache
+	server       *Server
+	retriever    Retriever
+	flight       *singleflight.Flight
+	staleWindow  time.Duration           // 软过期窗口, 0 表示不启用
+	refreshing   sync.Map                // 正在后台刷新的Key
+	keys         keyLocks                // 回源结果与 Set/Delete 之间的并发控制
+	negative     cachememory.CacheMemory // 负缓存, 记录数据源中不存在的Key, 为 nil 时不启用
+	negativeTTL  time.Duration
+	loadTTL      time.Duration // 取回数据的默认过期时间, NoExpiry 表示永不过期
+	loadJitter   time.Duration // 默认过期时间上追加 [0, loadJitter) 的随机时长, 避免同时过期
+	loadTimeout  time.Duration // 一次回源(含重试)的超时时间, 0 表示不限制
+	loadStats    LoadStats
+	writer       Writer        // 为 nil 时 Set/Delete 只更新缓存
+	writeTimeout time.Duration // 每次写入数据源的超时时间, 0 表示不限制
+	flusher      *flusher      // write-behind 模式下的后台写入, write-through 模式下为 nil
+	writeStats   WriteStats
+	breaker      *breaker // 回源熔断器, 为 nil 时不启用
+	maxRetries   int      // 回源失败的最大重试次数
+	backoff      time.Duration
+	maxBackoff   time.Duration
+}
+
+// LoadStats 回源相关的统计
+type LoadStats struct {
+	StaleHits       int64 // 软过期后仍返回缓存值的次数
+	Refreshes       int64 // 后台刷新次数
+	RefreshFailures int64 // 后台刷新失败次数
+	NegativeHits    int64 // 命中负缓存、未回源的次数
+	NegativeEntries int   // 负缓存中的Key数量
+	Retries         int64 // 回源失败后的重试次数
 }
 
-func NewSaberCache(maxBytes int64, strategy string, retriever Retriever) *SaberCache {
+// Option SaberCache 的可选配置
+type Option func(*SaberCache)
+
+// WithStaleWhileRevalidate Key剩余有效期不足 window 时视为软过期: Get 立即返回缓存值,
+// 同时通过 singleflight 在后台向 Retriever 刷新一次; 硬过期后与未命中相同, 同步回源
+func WithStaleWhileRevalidate(window time.Duration) Option {
+	return func(sc *SaberCache) {
+		sc.staleWindow = window
+	}
+}
+
+// WithNegativeCache Retriever 返回 ErrNotFound 的Key在 ttl 内不再回源, 直接返回 ErrNotFound,
+// 最多记录 maxEntries 个Key(按LRU淘汰), 0 表示不限制
+func WithNegativeCache(ttl time.Duration, maxEntries int) Option {
+	return func(sc *SaberCache) {
+		if ttl <= 0 {
+			return
+		}
+		sc.negativeTTL = ttl
+		sc.negative = cachememory.NewLRUCache(0, nil)
+		sc.negative.SetLimit(0, maxEntries)
+	}
+}
+
+// WithLoadTTL 取回数据的默认过期时间为 ttl 加上 [0, jitter) 的随机时长, ttl 为0时使用 DefaultLoadTTL,
+// 为 NoExpiry 时永不过期; Retriever 通过 RetrieverWithTTLFunc 返回的TTL优先
+func WithLoadTTL(ttl, jitter time.Duration) Option {
+	return func(sc *SaberCache) {
+		if ttl == 0 {
+			ttl = DefaultLoadTTL
+		}
+		sc.loadTTL, sc.loadJitter = ttl, jitter
+	}
+}
+
+// WithLoadTimeout 一次回源(含重试)的超时时间, 0 表示不限制。回源由等待同一Key的请求共享,
+// 不使用任何一个调用方的截止时间, 调用方仍可随自身 ctx 放弃等待
+func WithLoadTimeout(timeout time.Duration) Option {
+	return func(sc *SaberCache) {
+		if timeout < 0 {
+			timeout = 0
+		}
+		sc.loadTimeout = timeout
+	}
+}
+
+// NewSaberCache 创建默认缓存组, 默认组已存在时原子地替换为新实例后再 Close 原有实例; 需要多个缓存组时使用 NewGroup
+func NewSaberCache(maxBytes int64, strategy string, retriever Retriever, opts ...Option) *SaberCache {
+	sc := newSaberCache(DefaultGroup, maxBytes, strategy, retriever, opts...)
+	replaceGroup(DefaultGroup, sc)
+	return sc
+}
+
+func newSaberCache(name string, maxBytes int64, strategy string, retriever Retriever, opts ...Option) *SaberCache {
 	if retriever == nil {
 		panic("Group retriever must be existed!")
 	}
 	sc := &SaberCache{
-		cache:     newCache(maxBytes, strategy),
-		retriever: retriever,
-		flight:    &singleflight.Flight{},
+		name:         name,
+		cache:        newCache(maxBytes, strategy),
+		retriever:    retriever,
+		flight:       &singleflight.Flight{},
+		loadTTL:      DefaultLoadTTL,
+		loadTimeout:  DefaultLoadTimeout,
+		writeTimeout: DefaultWriteTimeout,
+	}
+	sc.cache.backup = backupFile(name)
+	for _, opt := range opts {
+		opt(sc)
+	}
+	if sc.flusher != nil {
+		sc.flusher.start(sc.writeTimeout)
 	}
-	sabercache = sc
 	sc.cache.Init()
 	go sc.cache.BgSave()
 	return sc
 }
+
+// Name 返回缓存组名称
+func (sc *SaberCache) Name() string {
+	return sc.name
+}
 func (sc *SaberCache) RegisterSvr(svr *Server) {
 	if sc.server != nil {
 		panic("SaberCache had been registered server")
@@ -47,22 +192,214 @@ func (sc *SaberCache) RegisterSvr(svr *Server) {
 	sc.server = svr
 }
 func (sc *SaberCache) Set(key string, value ByteView, ttl int64) bool {
-	if ttl == -1 {
-		sc.cache.SetWithoutTTL(key, value)
-	} else {
-		sc.cache.SetWithTTL(key, value, ttl)
-	}
-	return true
+	return sc.update(&writeOp{key: key, value: value.ByteSlice()}, func() {
+		if ttl == -1 {
+			sc.cache.SetWithoutTTL(key, value)
+		} else {
+			sc.cache.SetWithTTL(key, value, ttl)
+		}
+	})
+}
+
+// PSet 写入Key并设置毫秒级过期时间, pttl 为 -1 时永不过期
+func (sc *SaberCache) PSet(key string, value ByteView, pttl int64) bool {
+	return sc.update(&writeOp{key: key, value: value.ByteSlice()}, func() {
+		if pttl == -1 {
+			sc.cache.SetWithoutTTL(key, value)
+		} else {
+			sc.cache.SetWithPTTL(key, value, pttl)
+		}
+	})
+}
+
+// SetSliding 写入Key并设置滑动过期时间, Key连续 pttl 毫秒未被读取后过期, TTL 返回剩余的空闲时间
+func (sc *SaberCache) SetSliding(key string, value ByteView, pttl int64) bool {
+	return sc.update(&writeOp{key: key, value: value.ByteSlice()}, func() {
+		sc.cache.SetWithSlidingPTTL(key, value, pttl)
+	})
 }
 func (sc *SaberCache) Get(key string) (ByteView, error) {
+	return sc.GetContext(context.Background(), key)
+}
+
+// GetContext 与 Get 相同, 未命中时 ctx 传递给 Retriever; ctx 结束时立即返回 ctx.Err(),
+// 已发起的回源继续执行并填充缓存
+func (sc *SaberCache) GetContext(ctx context.Context, key string) (ByteView, error) {
 	if key == "" {
 		return ByteView{}, fmt.Errorf("key required")
 	}
 	if value, ok := sc.cache.Get(key); ok {
 		log.Println("cache hit")
+		if sc.staleWindow > 0 {
+			sc.revalidate(key)
+		}
 		return value, nil
 	}
-	return sc.load(key)
+	if sc.negative != nil {
+		if _, ok := sc.negative.Get(key); ok {
+			atomic.AddInt64(&sc.loadStats.NegativeHits, 1)
+			return ByteView{}, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}
+	}
+	return sc.load(ctx, key)
+}
+
+// GetMulti 批量获取多个Key, 数据源中不存在的Key不出现在返回结果中。
+// 未命中的Key中已在加载的等待原有任务, 其余合并回源: Retriever 实现了 BatchRetriever 时只调用一次,
+// 否则逐个并发回源。有Key回源失败时返回已取得的结果与第一个错误
+func (sc *SaberCache) GetMulti(ctx context.Context, keys []string) (map[string]ByteView, error) {
+	values := make(map[string]ByteView, len(keys))
+	var misses []string
+	for _, key := range keys {
+		if key == "" {
+			return values, fmt.Errorf("key required")
+		}
+		if value, ok := sc.cache.Get(key); ok {
+			if sc.staleWindow > 0 {
+				sc.revalidate(key)
+			}
+			values[key] = value
+			continue
+		}
+		if sc.negative != nil {
+			if _, ok := sc.negative.Get(key); ok {
+				atomic.AddInt64(&sc.loadStats.NegativeHits, 1)
+				continue
+			}
+		}
+		misses = append(misses, key)
+	}
+	if len(misses) == 0 {
+		return values, nil
+	}
+	results := sc.flight.FlyMulti(ctx, misses, func(keys []string) map[string]singleflight.Result {
+		loadCtx, cancel := detach(ctx, sc.loadTimeout)
+		defer cancel()
+		return sc.getBatchLocally(loadCtx, keys)
+	})
+	var firstErr error
+	for _, key := range misses {
+		result, ok := results[key]
+		if !ok {
+			continue
+		}
+		switch {
+		case result.Err == nil:
+			values[key] = result.Val.(ByteView)
+		case errors.Is(result.Err, ErrNotFound):
+		case firstErr == nil:
+			firstErr = result.Err
+		}
+	}
+	return values, firstErr
+}
+
+// getBatchLocally 批量回源并填充缓存, 回源耗时按Key数量均摊为各Key的重新计算代价;
+// write-behind 队列中的Key不回源
+func (sc *SaberCache) getBatchLocally(ctx context.Context, keys []string) map[string]singleflight.Result {
+	results := make(map[string]singleflight.Result, len(keys))
+	br, ok := sc.retriever.(BatchRetriever)
+	if !ok {
+		var (
+			mu sync.Mutex
+			wg sync.WaitGroup
+		)
+		for _, key := range keys {
+			wg.Add(1)
+			go func(key string) {
+				defer wg.Done()
+				value, err := sc.getLocally(ctx, key)
+				mu.Lock()
+				results[key] = singleflight.Result{Val: value, Err: err}
+				mu.Unlock()
+			}(key)
+		}
+		wg.Wait()
+		return results
+	}
+	versions := make(map[string]uint64, len(keys))
+	missing := make([]string, 0, len(keys))
+	for _, key := range keys {
+		versions[key] = sc.keys.version(key)
+		if value, err, ok := sc.getPending(key, versions[key]); ok {
+			results[key] = singleflight.Result{Val: value, Err: err}
+			continue
+		}
+		missing = append(missing, key)
+	}
+	if len(missing) == 0 {
+		return results
+	}
+	keys = missing
+	start := time.Now()
+	var batch map[string][]byte
+	err := sc.retrieve(ctx, func() (err error) {
+		batch, err = retrieveBatch(ctx, br, keys)
+		return err
+	})
+	if err != nil {
+		for _, key := range keys {
+			results[key] = singleflight.Result{Err: err}
+		}
+		return results
+	}
+	cost := time.Since(start).Microseconds() / int64(len(keys))
+	for _, key := range keys {
+		bytes, ok := batch[key]
+		if !ok {
+			sc.keys.ifUnchanged(key, versions[key], func() { sc.rememberMiss(key) })
+			results[key] = singleflight.Result{Err: fmt.Errorf("%w: %s", ErrNotFound, key)}
+			continue
+		}
+		value := ByteView{bytes: cloneBytes(bytes)}
+		sc.keys.ifUnchanged(key, versions[key], func() { sc.populateCache(key, value, 0, cost) })
+		results[key] = singleflight.Result{Val: value}
+	}
+	return results
+}
+
+// rememberMiss 记录数据源中不存在的Key
+func (sc *SaberCache) rememberMiss(key string) {
+	if sc.negative != nil {
+		sc.negative.SetWithPTTL(key, ByteView{}, sc.negativeTTL.Milliseconds())
+	}
+}
+
+// forgetMiss Key被写入时移出负缓存
+func (sc *SaberCache) forgetMiss(key string) {
+	if sc.negative != nil {
+		sc.negative.Delete(key)
+	}
+}
+
+// revalidate 从数据源取回的Key处于软过期窗口时在后台刷新, 同一Key同时只有一个刷新任务;
+// 通过 Set 写入的Key不刷新, 以免被数据源中的值覆盖
+func (sc *SaberCache) revalidate(key string) {
+	if !sc.cache.Loaded(key) {
+		return
+	}
+	pttl := sc.cache.PTTL(key)
+	if pttl < 0 || pttl > sc.staleWindow.Milliseconds() {
+		return
+	}
+	atomic.AddInt64(&sc.loadStats.StaleHits, 1)
+	if _, loaded := sc.refreshing.LoadOrStore(key, struct{}{}); loaded {
+		return
+	}
+	atomic.AddInt64(&sc.loadStats.Refreshes, 1)
+	go func() {
+		defer sc.refreshing.Delete(key)
+		version := sc.keys.version(key)
+		_, err := sc.load(context.Background(), key)
+		if errors.Is(err, ErrNotFound) {
+			// 数据源中已不存在, 删除旧值; 刷新期间Key被重新写入时保留
+			sc.keys.ifUnchanged(key, version, func() { sc.cache.Delete(key) })
+		}
+		if err != nil {
+			atomic.AddInt64(&sc.loadStats.RefreshFailures, 1)
+			log.Printf("refresh %s failed: %v", key, err)
+		}
+	}()
 }
 func (sc *SaberCache) GetAll() (kv []*cachememory.Entity) {
 	return sc.cache.GetAll()
@@ -74,30 +411,245 @@ func (sc *SaberCache) Save() bool {
 func (sc *SaberCache) TTL(key string) int64 {
 	return sc.cache.TTL(key)
 }
-func (sc *SaberCache) load(key string) (ByteView, error) {
-	view, err := sc.flight.Fly(key, func() (any, error) {
-		return sc.getLocally(key)
+
+// PTTL 返回Key剩余的毫秒数
+func (sc *SaberCache) PTTL(key string) int64 {
+	return sc.cache.PTTL(key)
+}
+
+// NegativeTTL 返回Key在负缓存中剩余的秒数, -2 表示Key未被负缓存
+func (sc *SaberCache) NegativeTTL(key string) int64 {
+	if sc.negative == nil {
+		return -2
+	}
+	return sc.negative.TTL(key)
+}
+
+// NegativePTTL 返回Key在负缓存中剩余的毫秒数, -2 表示Key未被负缓存
+func (sc *SaberCache) NegativePTTL(key string) int64 {
+	if sc.negative == nil {
+		return -2
+	}
+	return sc.negative.PTTL(key)
+}
+
+// Stats 返回缓存占用与容量上限
+func (sc *SaberCache) Stats() cachememory.Stats {
+	return sc.cache.Stats()
+}
+
+// LoadStats 返回回源相关的统计
+func (sc *SaberCache) LoadStats() LoadStats {
+	stats := LoadStats{
+		StaleHits:       atomic.LoadInt64(&sc.loadStats.StaleHits),
+		Refreshes:       atomic.LoadInt64(&sc.loadStats.Refreshes),
+		RefreshFailures: atomic.LoadInt64(&sc.loadStats.RefreshFailures),
+		NegativeHits:    atomic.LoadInt64(&sc.loadStats.NegativeHits),
+		Retries:         atomic.LoadInt64(&sc.loadStats.Retries),
+	}
+	if sc.negative != nil {
+		stats.NegativeEntries = sc.negative.Len()
+	}
+	return stats
+}
+
+// EvictStats 返回按原因统计的移除次数与幽灵列表命中情况
+func (sc *SaberCache) EvictStats() EvictStats {
+	return sc.cache.EvictStats()
+}
+
+// Delete 删除本节点缓存的Key, 返回Key是否存在, 同时清除该Key的负缓存;
+// 配置了 Writer 时一并删除数据源中的Key, 失败时不删除缓存并返回 false
+func (sc *SaberCache) Delete(key string) bool {
+	var existed bool
+	ok := sc.update(&writeOp{key: key, deleted: true}, func() {
+		existed = sc.cache.Delete(key)
+	})
+	return ok && existed
+}
+
+// update 持有Key所在分段的锁, 先写入数据源成功后再清除负缓存并执行 fn 更新缓存,
+// 使并发写入同一Key时数据源与缓存的最终值一致; 返回是否写入成功
+func (sc *SaberCache) update(op *writeOp, fn func()) bool {
+	var ok bool
+	sc.keys.write(op.key, func() {
+		if ok = sc.persist(op); ok {
+			sc.forgetMiss(op.key)
+			fn()
+		}
+	})
+	return ok
+}
+
+// persist 按配置的写入模式将写入同步到数据源, 返回是否可以继续更新缓存
+func (sc *SaberCache) persist(op *writeOp) bool {
+	switch {
+	case sc.flusher != nil:
+		if err := sc.flusher.enqueue(op); err != nil {
+			log.Printf("write-behind %s rejected: %v", op.key, err)
+			return false
+		}
+	case sc.writer != nil:
+		if err := write(context.Background(), sc.writer, op, sc.writeTimeout); err != nil {
+			atomic.AddInt64(&sc.writeStats.Failures, 1)
+			log.Printf("write-through %s failed: %v", op.key, err)
+			return false
+		}
+		atomic.AddInt64(&sc.writeStats.Flushed, 1)
+	}
+	return true
+}
+
+// WriteStats 返回写入数据源相关的统计
+func (sc *SaberCache) WriteStats() WriteStats {
+	if sc.flusher != nil {
+		return sc.flusher.Stats()
+	}
+	return WriteStats{
+		Flushed:  atomic.LoadInt64(&sc.writeStats.Flushed),
+		Failures: atomic.LoadInt64(&sc.writeStats.Failures),
+	}
+}
+
+// Close 停止接收 write-behind 写入, 等待队列中的数据写入数据源或 ctx 结束, 之后停止缓存的水位保护与后台任务
+func (sc *SaberCache) Close(ctx context.Context) error {
+	defer sc.cache.Stop()
+	if sc.negative != nil {
+		defer sc.negative.Stop()
+	}
+	if sc.flusher == nil {
+		return nil
+	}
+	return sc.flusher.close(ctx)
+}
+
+// SetStrategy 在线切换淘汰策略, 已有的Key与过期时间会迁移到新策略中
+func (sc *SaberCache) SetStrategy(strategy string) error {
+	return sc.cache.SetStrategy(strategy)
+}
+
+// Strategy 返回当前的淘汰策略
+func (sc *SaberCache) Strategy() string {
+	return sc.cache.Strategy()
+}
+
+// load 同一Key同时只回源一次; 回源使用发起者 ctx 的值, 但不继承其截止时间与取消, 改由 loadTimeout 限制,
+// 以免发起者放弃或超时后其他等待者拿不到结果
+func (sc *SaberCache) load(ctx context.Context, key string) (ByteView, error) {
+	view, err, _ := sc.flight.FlyContext(ctx, key, func() (any, error) {
+		loadCtx, cancel := detach(ctx, sc.loadTimeout)
+		defer cancel()
+		return sc.getLocally(loadCtx, key)
 	})
 	if err != nil {
 		return ByteView{}, err
 	}
 	return view.(ByteView), nil
+}
+
+// detachedContext 保留 parent 的值, 但不继承其截止时间与取消
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
 
+func (detachedContext) Done() <-chan struct{} {
+	return nil
 }
 
-// getLocally 本地向Retriever取回数据并填充缓存
-func (sc *SaberCache) getLocally(key string) (ByteView, error) {
-	bytes, err := sc.retriever.retrieve(key)
+func (detachedContext) Err() error {
+	return nil
+}
+
+// detach 返回保留 ctx 的值、不随其取消、在 timeout 后超时的 context, timeout 为0时不超时
+func detach(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(detachedContext{ctx}, timeout)
+	}
+	return context.WithCancel(detachedContext{ctx})
+}
+
+// getPending 尚未写入数据源的Key以 write-behind 队列中的为准, 避免从数据源取回旧值; ok 为 false 时Key不在队列中
+func (sc *SaberCache) getPending(key string, version uint64) (value ByteView, err error, ok bool) {
+	if sc.flusher == nil {
+		return
+	}
+	op, ok := sc.flusher.lookup(key)
+	if !ok {
+		return
+	}
+	if op.deleted {
+		return ByteView{}, fmt.Errorf("%w: %s", ErrNotFound, key), true
+	}
+	value = ByteView{bytes: cloneBytes(op.value)}
+	// 队列中的值由客户端写入, 不标记为从数据源取回
+	sc.keys.ifUnchanged(key, version, func() { sc.cache.SetWithPTTLCost(key, value, sc.loadPTTL(0), 0) })
+	return value, nil, true
+}
+
+// getLocally 本地向Retriever取回数据并填充缓存, 取回耗时(微秒)作为该Key的重新计算代价
+func (sc *SaberCache) getLocally(ctx context.Context, key string) (ByteView, error) {
+	version := sc.keys.version(key)
+	if value, err, ok := sc.getPending(key, version); ok {
+		return value, err
+	}
+	var (
+		bytes []byte
+		ttl   time.Duration
+		err   error
+	)
+	start := time.Now()
+	nsCtx, rawKey := withNamespace(ctx, key)
+	err = sc.retrieve(ctx, func() (err error) {
+		if r, ok := sc.retriever.(TTLRetriever); ok {
+			bytes, ttl, err = r.RetrieveWithTTL(nsCtx, rawKey)
+		} else {
+			bytes, err = sc.retriever.Retrieve(nsCtx, rawKey)
+		}
+		return err
+	})
+	if errors.Is(err, ErrNotFound) {
+		// 回源期间Key被写入时不记录负缓存
+		sc.keys.ifUnchanged(key, version, func() { sc.rememberMiss(key) })
+	}
 	if err != nil {
 		return ByteView{}, err
 	}
 	value := ByteView{bytes: cloneBytes(bytes)}
-	sc.populateCache(key, value)
+	cost := time.Since(start).Microseconds()
+	// 回源期间Key被写入时不覆盖写入的值
+	sc.keys.ifUnchanged(key, version, func() { sc.populateCache(key, value, ttl, cost) })
 	return value, nil
 }
 
-// populateCache 提供填充缓存的能力
-func (sc *SaberCache) populateCache(key string, value ByteView) {
-	ttl := rand.Int63n(60)
-	sc.cache.SetWithTTL(key, value, ttl)
+// populateCache 用从数据源取回的值填充缓存, ttl 为0时使用默认过期时间
+func (sc *SaberCache) populateCache(key string, value ByteView, ttl time.Duration, cost int64) {
+	sc.cache.SetLoaded(key, value, sc.loadPTTL(ttl), cost)
+}
+
+// loadPTTL 将取回数据的过期时间换算为毫秒, ttl 为0时使用默认过期时间, 永不过期时返回 -1
+func (sc *SaberCache) loadPTTL(ttl time.Duration) int64 {
+	if ttl == 0 {
+		ttl = sc.defaultLoadTTL()
+	}
+	if ttl < 0 {
+		return -1
+	}
+	// 不足1毫秒按1毫秒计, 避免写入后立即过期
+	pttl := ttl.Milliseconds()
+	if pttl <= 0 {
+		pttl = 1
+	}
+	return pttl
+}
+
+// defaultLoadTTL 默认过期时间加上随机抖动
+func (sc *SaberCache) defaultLoadTTL() time.Duration {
+	if sc.loadTTL < 0 || sc.loadJitter <= 0 {
+		return sc.loadTTL
+	}
+	return sc.loadTTL + time.Duration(rand.Int63n(int64(sc.loadJitter)))
 }
@@ -1,10 +1,20 @@
 package sabercache_server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"reflect"
 	"sabercache_server/cachememory"
+	"sabercache_server/util"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestGet(t *testing.T) {
@@ -169,3 +179,960 @@ func TestInit(t *testing.T) {
 		}
 	})
 }
+func TestSetStrategy(t *testing.T) {
+	c := newCache(2<<10, "lru")
+	c.SetWithoutTTL("k1", ByteView{[]byte("v1")})
+	c.SetWithTTL("k2", ByteView{[]byte("v2")}, 100)
+	before := c.Stats()
+	t.Run("Switch", func(t *testing.T) {
+		if err := c.SetStrategy("lfu"); err != nil {
+			t.Fatal(err)
+		}
+		if c.Strategy() != "lfu" {
+			t.Fatalf("strategy not switched")
+		}
+		if v, ok := c.Get("k1"); !ok || v.String() != "v1" {
+			t.Fatalf("k1 lost after switch")
+		}
+		if ttl := c.TTL("k1"); ttl != -1 {
+			t.Fatalf("unexpected ttl %d", ttl)
+		}
+		if ttl := c.TTL("k2"); ttl <= 98 || ttl > 100 {
+			t.Fatalf("unexpected ttl %d", ttl)
+		}
+		if after := c.Stats(); after != before {
+			t.Fatalf("stats changed %+v -> %+v", before, after)
+		}
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		// 切换期间的写入不会丢失
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			for i := 0; i < 100; i++ {
+				c.SetWithoutTTL("c"+strconv.Itoa(i), ByteView{[]byte("v")})
+			}
+		}()
+		for _, s := range []string{"arc", "fifo", "tinylfu", "lfu"} {
+			if err := c.SetStrategy(s); err != nil {
+				t.Fatal(err)
+			}
+		}
+		<-done
+		for i := 0; i < 100; i++ {
+			if _, ok := c.Get("c" + strconv.Itoa(i)); !ok {
+				t.Fatalf("c%d lost during switch", i)
+			}
+		}
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		if err := c.SetStrategy("unknown"); err == nil || c.Strategy() != "lfu" {
+			t.Fatalf("unknown strategy accepted")
+		}
+	})
+}
+
+func TestEvictStats(t *testing.T) {
+	defer func(n int) { util.GhostEntries = n }(util.GhostEntries)
+	util.GhostEntries = 8
+	c := newCache(2<<10, "lru")
+	c.SetLimit(0, 2)
+	for _, k := range []string{"k1", "k2", "k3"} {
+		c.SetWithoutTTL(k, ByteView{[]byte("v")})
+	}
+	c.SetWithoutTTL("k3", ByteView{[]byte("v3")})
+	if !c.Delete("k2") || c.Delete("k2") {
+		t.Fatalf("delete failed")
+	}
+	// k1 因容量不足被淘汰, 再次访问计为一次幽灵命中
+	if _, ok := c.Get("k1"); ok {
+		t.Fatalf("k1 not evicted")
+	}
+	stats := c.EvictStats()
+	expect := map[string]int64{"capacity": 1, "expired": 0, "deleted": 1, "replaced": 1}
+	if !reflect.DeepEqual(stats.Evictions, expect) {
+		t.Fatalf("unexpected evictions %v", stats.Evictions)
+	}
+	if stats.Ghost.Hits != 1 || stats.Ghost.HitBytes != 3 || stats.Ghost.Entries != 0 {
+		t.Fatalf("unexpected ghost stats %+v", stats.Ghost)
+	}
+	// k3 被淘汰后重新写入, 移出幽灵列表
+	c.SetWithoutTTL("k4", ByteView{[]byte("v")})
+	c.SetWithoutTTL("k5", ByteView{[]byte("v")})
+	if c.EvictStats().Ghost.Entries != 1 {
+		t.Fatalf("k3 not recorded in ghost list")
+	}
+	c.SetWithoutTTL("k3", ByteView{[]byte("v3")})
+	c.Delete("k3")
+	c.Get("k3")
+	if stats := c.EvictStats().Ghost; stats.Hits != 1 || stats.Entries != 1 {
+		t.Fatalf("re-inserted key should leave ghost list, got %+v", stats)
+	}
+}
+
+func TestStaleWhileRevalidate(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		loads = make(map[string]int)
+	)
+	sc := NewSaberCache(2<<10, "lru", RetrieverWithTTLFunc(
+		func(ctx context.Context, key string) ([]byte, time.Duration, error) {
+			mu.Lock()
+			loads[key]++
+			n := loads[key]
+			mu.Unlock()
+			// 首次回源的值在软过期窗口内
+			if n == 1 {
+				return []byte("old"), 500 * time.Millisecond, nil
+			}
+			time.Sleep(50 * time.Millisecond)
+			if key == "bad" {
+				return nil, 0, fmt.Errorf("%s not exist", key)
+			}
+			return []byte("new"), 0, nil
+		}), WithStaleWhileRevalidate(time.Second))
+	sc.Get("swr")
+	sc.Get("bad")
+	// 软过期窗口内立即返回旧值, 只触发一次后台刷新
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := sc.Get("swr"); err != nil || v.String() != "old" {
+				t.Errorf("expect stale value, got %s %v", v.String(), err)
+			}
+		}()
+	}
+	wg.Wait()
+	sc.Get("bad")
+	time.Sleep(200 * time.Millisecond)
+	// 刷新后的TTL随机, 可能已过期, 只确认旧值被替换
+	if v, _ := sc.cache.Get("swr"); v.String() == "old" {
+		t.Fatalf("swr not refreshed")
+	}
+	if v, _ := sc.cache.Get("bad"); v.String() != "old" {
+		t.Fatalf("failed refresh should keep stale value, got %s", v.String())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if loads["swr"] != 2 || loads["bad"] != 2 {
+		t.Fatalf("expect 2 loads for each key, got %v", loads)
+	}
+	if stats := sc.LoadStats(); stats != (LoadStats{StaleHits: 11, Refreshes: 2, RefreshFailures: 1}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+// TestStaleWhileRevalidateWritten 客户端写入的Key不是从数据源取回的, 软过期时不刷新
+func TestStaleWhileRevalidateWritten(t *testing.T) {
+	var calls int32
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			return []byte("origin"), nil
+		}), WithStaleWhileRevalidate(time.Second))
+	sc.PSet("written", ByteView{[]byte("client")}, 500)
+	if v, err := sc.Get("written"); err != nil || v.String() != "client" {
+		t.Fatalf("unexpected value %s %v", v.String(), err)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if v, _ := sc.cache.Get("written"); v.String() != "client" {
+		t.Fatalf("written key overwritten by refresh: %s", v.String())
+	}
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Fatalf("expect no retriever calls, got %d", n)
+	}
+	if stats := sc.LoadStats(); stats.StaleHits != 0 || stats.Refreshes != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestNegativeCache(t *testing.T) {
+	var calls int32
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			if key == "flaky" {
+				return nil, fmt.Errorf("connection reset")
+			}
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}), WithNegativeCache(200*time.Millisecond, 10))
+	t.Run("NotFound", func(t *testing.T) {
+		for i := 0; i < 3; i++ {
+			if _, err := sc.Get("missing"); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expect ErrNotFound, got %v", err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("expect 1 retriever call, got %d", n)
+		}
+		if pttl := sc.NegativePTTL("missing"); pttl <= 0 || pttl > 200 {
+			t.Fatalf("unexpected negative pttl %d", pttl)
+		}
+		if stats := sc.LoadStats(); stats.NegativeHits != 2 || stats.NegativeEntries != 1 {
+			t.Fatalf("unexpected stats %+v", stats)
+		}
+	})
+	t.Run("Transient", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		sc.Get("flaky")
+		sc.Get("flaky")
+		if n := atomic.LoadInt32(&calls); n != 2 || sc.NegativeTTL("flaky") != -2 {
+			t.Fatalf("transient error should not be cached, calls %d", n)
+		}
+	})
+	t.Run("Set", func(t *testing.T) {
+		sc.Set("missing", ByteView{[]byte("v")}, -1)
+		if v, err := sc.Get("missing"); err != nil || v.String() != "v" {
+			t.Fatalf("set should clear negative cache, got %v", err)
+		}
+	})
+	t.Run("Expire", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		sc.Get("gone")
+		time.Sleep(250 * time.Millisecond)
+		sc.Get("gone")
+		if n := atomic.LoadInt32(&calls); n != 2 {
+			t.Fatalf("expect 2 retriever calls, got %d", n)
+		}
+	})
+}
+
+// TestNotFoundDuringSet 回源期间写入的Key不会被随后返回的 ErrNotFound 删除或记入负缓存
+func TestNotFoundDuringSet(t *testing.T) {
+	entered, release := make(chan struct{}), make(chan struct{})
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			close(entered)
+			<-release
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}), WithNegativeCache(time.Minute, 10))
+	done := make(chan error)
+	go func() {
+		_, err := sc.Get("racy")
+		done <- err
+	}()
+	<-entered
+	sc.Set("racy", ByteView{[]byte("written")}, -1)
+	close(release)
+	if err := <-done; !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expect ErrNotFound from the load, got %v", err)
+	}
+	if v, err := sc.Get("racy"); err != nil || v.String() != "written" {
+		t.Fatalf("written value lost: %s %v", v.String(), err)
+	}
+	if ttl := sc.NegativeTTL("racy"); ttl != -2 {
+		t.Fatalf("written key should not be negative cached, ttl %d", ttl)
+	}
+}
+
+func TestLoadTTL(t *testing.T) {
+	retriever := RetrieverWithTTLFunc(func(ctx context.Context, key string) ([]byte, time.Duration, error) {
+		switch key {
+		case "short":
+			return []byte("v"), 300 * time.Millisecond, nil
+		case "forever":
+			return []byte("v"), NoExpiry, nil
+		}
+		return []byte("v"), 0, nil
+	})
+	t.Run("Retriever", func(t *testing.T) {
+		sc := NewSaberCache(2<<10, "lru", retriever)
+		sc.Get("short")
+		sc.Get("forever")
+		sc.Get("default")
+		if pttl := sc.PTTL("short"); pttl <= 0 || pttl > 300 {
+			t.Fatalf("unexpected pttl %d", pttl)
+		}
+		if ttl := sc.TTL("forever"); ttl != -1 {
+			t.Fatalf("unexpected ttl %d", ttl)
+		}
+		if ttl := sc.TTL("default"); ttl != int64(DefaultLoadTTL/time.Second) {
+			t.Fatalf("unexpected ttl %d", ttl)
+		}
+	})
+	t.Run("Jitter", func(t *testing.T) {
+		sc := NewSaberCache(2<<10, "lru", retriever, WithLoadTTL(10*time.Second, 5*time.Second))
+		for i := 0; i < 20; i++ {
+			key := "jitter" + strconv.Itoa(i) // 避免与 Init 加载的 backup.txt 中的Key重复
+			sc.Get(key)
+			if pttl := sc.PTTL(key); pttl <= 9900 || pttl > 15000 {
+				t.Fatalf("pttl %d out of range", pttl)
+			}
+		}
+	})
+	t.Run("NoExpiry", func(t *testing.T) {
+		sc := NewSaberCache(2<<10, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+			return []byte("v"), nil
+		}), WithLoadTTL(NoExpiry, time.Second))
+		sc.Get("k")
+		if ttl := sc.TTL("k"); ttl != -1 {
+			t.Fatalf("unexpected ttl %d", ttl)
+		}
+	})
+}
+
+func TestGetContext(t *testing.T) {
+	var calls int32
+	release := make(chan struct{})
+	sc := NewSaberCache(2<<10, "lru", ContextRetrieverFunc(
+		func(ctx context.Context, key string) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			switch key {
+			case "deadline":
+				// 回源使用 loadTimeout, 不继承调用方的截止时间
+				if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) < DefaultLoadTimeout/2 {
+					return nil, fmt.Errorf("unexpected deadline %v", deadline)
+				}
+				return []byte("v"), nil
+			case "short":
+				time.Sleep(60 * time.Millisecond)
+				return []byte("v"), ctx.Err()
+			}
+			<-release
+			// 发起者取消后回源仍继续
+			return []byte("v"), ctx.Err()
+		}))
+	t.Run("Deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if _, err := sc.GetContext(ctx, "deadline"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	t.Run("LeaderDeadline", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		leaderCtx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		leader := make(chan error)
+		go func() {
+			_, err := sc.GetContext(leaderCtx, "short")
+			leader <- err
+		}()
+		time.Sleep(5 * time.Millisecond)
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		v, err := sc.GetContext(ctx, "short")
+		if err != nil || v.String() != "v" {
+			t.Fatalf("waiter got %q, %v", v.String(), err)
+		}
+		if err := <-leader; !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expect deadline exceeded, got %v", err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("expect 1 retriever call, got %d", n)
+		}
+	})
+	t.Run("Abandon", func(t *testing.T) {
+		atomic.StoreInt32(&calls, 0)
+		ctx, cancel := context.WithCancel(context.Background())
+		leader := make(chan error)
+		go func() {
+			_, err := sc.GetContext(ctx, "slow")
+			leader <- err
+		}()
+		waiter := make(chan error)
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			v, err := sc.GetContext(context.Background(), "slow")
+			if err == nil && v.String() != "v" {
+				err = fmt.Errorf("unexpected value %s", v.String())
+			}
+			waiter <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+		if err := <-leader; !errors.Is(err, context.Canceled) {
+			t.Fatalf("expect canceled, got %v", err)
+		}
+		close(release)
+		if err := <-waiter; err != nil {
+			t.Fatal(err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Fatalf("expect 1 retriever call, got %d", n)
+		}
+		if _, ok := sc.cache.Get("slow"); !ok {
+			t.Fatalf("abandoned load not cached")
+		}
+	})
+}
+
+func TestLoadTimeout(t *testing.T) {
+	sc := NewSaberCache(2<<10, "lru", ContextRetrieverFunc(
+		func(ctx context.Context, key string) ([]byte, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}), WithLoadTimeout(30*time.Millisecond))
+	start := time.Now()
+	if _, err := sc.GetContext(context.Background(), "k"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expect deadline exceeded, got %v", err)
+	}
+	if d := time.Since(start); d > time.Second {
+		t.Fatalf("load not bounded by timeout: %v", d)
+	}
+}
+
+type batchRetriever struct {
+	mu      sync.Mutex
+	batches [][]string
+	release chan struct{}
+}
+
+func (r *batchRetriever) Retrieve(ctx context.Context, key string) ([]byte, error) {
+	<-r.release
+	return []byte("single-" + key), nil
+}
+
+func (r *batchRetriever) RetrieveBatch(ctx context.Context, keys []string) (map[string][]byte, error) {
+	r.mu.Lock()
+	r.batches = append(r.batches, keys)
+	r.mu.Unlock()
+	values := make(map[string][]byte)
+	for _, key := range keys {
+		if key != "missing" {
+			values[key] = []byte("batch-" + key)
+		}
+	}
+	return values, nil
+}
+
+func TestGetMulti(t *testing.T) {
+	t.Run("Batch", func(t *testing.T) {
+		r := &batchRetriever{release: make(chan struct{})}
+		sc := NewSaberCache(2<<10, "lru", r)
+		sc.Set("hit", ByteView{[]byte("v")}, -1)
+		// inflight 已在单独加载, 批量加载时等待原有任务
+		go sc.Get("inflight")
+		time.Sleep(20 * time.Millisecond)
+		time.AfterFunc(50*time.Millisecond, func() { close(r.release) })
+		values, err := sc.GetMulti(context.Background(), []string{"hit", "a", "b", "inflight", "missing", "a"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		expect := map[string]string{"hit": "v", "a": "batch-a", "b": "batch-b", "inflight": "single-inflight"}
+		if len(values) != len(expect) {
+			t.Fatalf("unexpected values %v", values)
+		}
+		for k, v := range expect {
+			if values[k].String() != v {
+				t.Fatalf("%s: expect %s, got %s", k, v, values[k].String())
+			}
+		}
+		if len(r.batches) != 1 || !reflect.DeepEqual(r.batches[0], []string{"a", "b", "missing"}) {
+			t.Fatalf("unexpected batches %v", r.batches)
+		}
+		// 批量取回的值已写入缓存
+		if _, err := sc.GetMulti(context.Background(), []string{"a", "b"}); err != nil || len(r.batches) != 1 {
+			t.Fatalf("batch values not cached")
+		}
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		var calls int32
+		sc := NewSaberCache(2<<10, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			if key == "flaky" {
+				return nil, fmt.Errorf("connection reset")
+			}
+			return []byte(key), nil
+		}))
+		values, err := sc.GetMulti(context.Background(), []string{"a", "b", "flaky"})
+		if err == nil || len(values) != 2 || values["b"].String() != "b" {
+			t.Fatalf("unexpected result %v %v", values, err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 3 {
+			t.Fatalf("expect 3 retriever calls, got %d", n)
+		}
+	})
+}
+
+type memWriter struct {
+	mu     sync.Mutex
+	data   map[string]string
+	fails  map[string]int // Key剩余的失败次数
+	gate   chan struct{}  // 不为 nil 时写入前等待
+	jitter time.Duration  // 大于0时写入后随机等待 [0, jitter) 再返回
+	writes []string
+}
+
+func newMemWriter() *memWriter {
+	return &memWriter{data: make(map[string]string), fails: make(map[string]int)}
+}
+
+func (w *memWriter) Write(ctx context.Context, key string, value []byte) error {
+	if w.gate != nil {
+		<-w.gate
+	}
+	defer w.delay()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.fails[key] > 0 {
+		w.fails[key]--
+		return fmt.Errorf("write %s failed", key)
+	}
+	w.data[key] = string(value)
+	w.writes = append(w.writes, key+"="+string(value))
+	return nil
+}
+
+func (w *memWriter) delay() {
+	if w.jitter > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(w.jitter))))
+	}
+}
+
+func (w *memWriter) Delete(ctx context.Context, key string) error {
+	defer w.delay()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.data, key)
+	w.writes = append(w.writes, "del "+key)
+	return nil
+}
+
+func TestWriteThrough(t *testing.T) {
+	w := newMemWriter()
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}), WithWriteThrough(w))
+	if !sc.Set("wt1", ByteView{[]byte("v1")}, -1) || w.data["wt1"] != "v1" {
+		t.Fatalf("write-through set failed")
+	}
+	w.fails["wt2"] = 1
+	if sc.Set("wt2", ByteView{[]byte("v2")}, -1) {
+		t.Fatalf("expect set failure")
+	}
+	if _, ok := sc.cache.Get("wt2"); ok {
+		t.Fatalf("failed write should not update cache")
+	}
+	if !sc.Delete("wt1") || len(w.data) != 0 {
+		t.Fatalf("write-through delete failed")
+	}
+	if stats := sc.WriteStats(); stats.Flushed != 2 || stats.Failures != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestWriteThroughConcurrent(t *testing.T) {
+	w := newMemWriter()
+	w.jitter = time.Millisecond
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+	}), WithWriteThrough(w))
+	for round := 0; round < 20; round++ {
+		key := fmt.Sprintf("wtc%d", round)
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				if i%4 == 3 {
+					sc.Delete(key)
+					return
+				}
+				sc.Set(key, ByteView{[]byte(strconv.Itoa(i))}, -1)
+			}(i)
+		}
+		wg.Wait()
+		w.mu.Lock()
+		stored, inStore := w.data[key]
+		w.mu.Unlock()
+		cached, inCache := sc.cache.Get(key)
+		if inStore != inCache || (inCache && cached.String() != stored) {
+			t.Fatalf("%s: store %q(%v), cache %q(%v)", key, stored, inStore, cached.String(), inCache)
+		}
+	}
+}
+
+func TestWriteBehind(t *testing.T) {
+	w := newMemWriter()
+	w.gate = make(chan struct{})
+	w.fails["wb3"] = 1
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+		return []byte("origin"), nil
+	}), WithWriteBehind(w, 2, 3, 10*time.Millisecond))
+	sc.Set("wb1", ByteView{[]byte("v1")}, -1)
+	time.Sleep(20 * time.Millisecond) // wb1 开始写入, 阻塞在 gate
+	sc.Set("wb2", ByteView{[]byte("a")}, -1)
+	sc.Set("wb2", ByteView{[]byte("b")}, -1)
+	sc.Set("wb3", ByteView{[]byte("v3")}, -1)
+	if sc.Set("wb4", ByteView{[]byte("v4")}, -1) {
+		t.Fatalf("expect queue full")
+	}
+	if stats := sc.WriteStats(); stats.QueueDepth != 2 || stats.Coalesced != 1 || stats.Rejected != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	// 尚未写入数据源的Key回源时以队列为准
+	sc.cache.Delete("wb2")
+	if v, err := sc.Get("wb2"); err != nil || v.String() != "b" {
+		t.Fatalf("expect queued value, got %s %v", v.String(), err)
+	}
+	close(w.gate)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sc.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(w.writes, []string{"wb1=v1", "wb2=b", "wb3=v3"}) {
+		t.Fatalf("unexpected writes %v", w.writes)
+	}
+	if stats := sc.WriteStats(); stats != (WriteStats{Flushed: 3, Coalesced: 1, Retries: 1, Rejected: 1}) {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	if sc.Set("wb5", ByteView{[]byte("v5")}, -1) {
+		t.Fatalf("expect set after close rejected")
+	}
+}
+
+// ctxWriter 写入阻塞到 ctx 结束
+type ctxWriter struct {
+	attempts int32
+}
+
+func (w *ctxWriter) Write(ctx context.Context, key string, value []byte) error {
+	atomic.AddInt32(&w.attempts, 1)
+	if _, ok := ctx.Deadline(); !ok {
+		return fmt.Errorf("write without timeout")
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (w *ctxWriter) Delete(ctx context.Context, key string) error {
+	return w.Write(ctx, key, nil)
+}
+
+// TestWriteBehindClose 每次写入受超时限制, Close 的 ctx 结束时取消重试并等待后台协程退出
+func TestWriteBehindClose(t *testing.T) {
+	w := &ctxWriter{}
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+		return []byte("origin"), nil
+	}), WithWriteBehind(w, 4, 1000, 5*time.Millisecond), WithWriteTimeout(10*time.Millisecond))
+	sc.Set("c1", ByteView{[]byte("v1")}, -1)
+	sc.Set("c2", ByteView{[]byte("v2")}, -1)
+	time.Sleep(50 * time.Millisecond)
+	if n := atomic.LoadInt32(&w.attempts); n < 2 {
+		t.Fatalf("expect timed out attempts to be retried, got %d", n)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := sc.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expect deadline exceeded, got %v", err)
+	}
+	select {
+	case <-sc.flusher.done:
+	default:
+		t.Fatalf("flusher still running after close")
+	}
+	attempts := atomic.LoadInt32(&w.attempts)
+	time.Sleep(30 * time.Millisecond)
+	if n := atomic.LoadInt32(&w.attempts); n != attempts {
+		t.Fatalf("writes continued after close: %d -> %d", attempts, n)
+	}
+	if stats := sc.WriteStats(); stats.Failures != 2 || stats.QueueDepth != 0 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+// TestWriteBehindBatch 批量回源时尚未写入数据源的Key同样以队列为准
+func TestWriteBehindBatch(t *testing.T) {
+	w := newMemWriter()
+	w.gate = make(chan struct{})
+	r := &batchRetriever{release: make(chan struct{})}
+	sc := NewSaberCache(2<<10, "lru", r, WithWriteBehind(w, 4, 0, 10*time.Millisecond))
+	sc.Set("wbb1", ByteView{[]byte("queued")}, -1)
+	sc.Delete("wbb2")
+	sc.cache.Delete("wbb1")
+	values, err := sc.GetMulti(context.Background(), []string{"wbb1", "wbb2", "wbb3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if values["wbb1"].String() != "queued" || values["wbb3"].String() != "batch-wbb3" {
+		t.Fatalf("unexpected values %v", values)
+	}
+	if _, ok := values["wbb2"]; ok {
+		t.Fatalf("deleted key should not be loaded")
+	}
+	if len(r.batches) != 1 || !reflect.DeepEqual(r.batches[0], []string{"wbb3"}) {
+		t.Fatalf("unexpected batches %v", r.batches)
+	}
+	close(w.gate)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := sc.Close(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestRetry 暂时性错误按退避重试, ErrNotFound 不重试
+func TestRetry(t *testing.T) {
+	var calls int32
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			n := atomic.AddInt32(&calls, 1)
+			if key == "flaky" && n < 3 {
+				return nil, fmt.Errorf("connection reset")
+			}
+			if key == "flaky" {
+				return []byte("v"), nil
+			}
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, key)
+		}), WithRetry(3, 10*time.Millisecond, 15*time.Millisecond))
+	if v, err := sc.Get("flaky"); err != nil || v.String() != "v" {
+		t.Fatalf("expect v after retries, got %v", err)
+	}
+	atomic.StoreInt32(&calls, 0)
+	if _, err := sc.Get("missing"); !errors.Is(err, ErrNotFound) || atomic.LoadInt32(&calls) != 1 {
+		t.Fatalf("not found should not retry, calls %d", calls)
+	}
+	if stats := sc.LoadStats(); stats.Retries != 2 {
+		t.Fatalf("expect 2 retries, got %+v", stats)
+	}
+}
+
+// TestCircuitBreaker 失败率达到阈值后熔断, 半开探测成功后恢复
+func TestCircuitBreaker(t *testing.T) {
+	var (
+		calls int32
+		down  int32 = 1
+	)
+	sc := NewSaberCache(2<<10, "lru", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			if atomic.LoadInt32(&down) == 1 {
+				return nil, fmt.Errorf("connection refused")
+			}
+			return []byte(key), nil
+		}), WithCircuitBreaker(0.5, 4, time.Second, 100*time.Millisecond, 2))
+	for i := 0; i < 4; i++ {
+		sc.Get("key" + strconv.Itoa(i))
+	}
+	if stats := sc.BreakerStats(); stats.State != BreakerOpen || stats.Opens != 1 {
+		t.Fatalf("expect open, got %+v", stats)
+	}
+	if _, err := sc.Get("key4"); !errors.Is(err, ErrCircuitOpen) || atomic.LoadInt32(&calls) != 4 {
+		t.Fatalf("expect ErrCircuitOpen without retrieving, got %v", err)
+	}
+	// 半开探测失败重新打开
+	time.Sleep(120 * time.Millisecond)
+	sc.Get("key5")
+	if stats := sc.BreakerStats(); stats.State != BreakerOpen || stats.Opens != 2 {
+		t.Fatalf("expect reopen, got %+v", stats)
+	}
+	// 半开探测全部成功后关闭
+	atomic.StoreInt32(&down, 0)
+	time.Sleep(120 * time.Millisecond)
+	sc.Get("key6")
+	if stats := sc.BreakerStats(); stats.State != BreakerHalfOpen {
+		t.Fatalf("expect half-open, got %+v", stats)
+	}
+	sc.Get("key7")
+	if stats := sc.BreakerStats(); stats.State != BreakerClosed || stats.Rejected != 1 {
+		t.Fatalf("expect closed, got %+v", stats)
+	}
+	if v, err := sc.Get("key8"); err != nil || v.String() != "key8" {
+		t.Fatalf("expect key8, got %v", err)
+	}
+}
+
+// TestBreakerStaleResult 调用方超时不计为失败, 熔断器状态变化前放行的回源结果不计入新状态
+func TestBreakerStaleResult(t *testing.T) {
+	var sc SaberCache
+	WithCircuitBreaker(0.5, 2, time.Second, 50*time.Millisecond, 1)(&sc)
+	b := sc.breaker
+	for i := 0; i < 4; i++ {
+		gen, _ := b.allow()
+		b.done(gen, context.DeadlineExceeded)
+	}
+	if stats := b.Stats(); stats.State != BreakerClosed || stats.Requests != 0 {
+		t.Fatalf("deadline exceeded should not be counted, got %+v", stats)
+	}
+	slow, _ := b.allow()
+	for i := 0; i < 2; i++ {
+		gen, _ := b.allow()
+		b.done(gen, fmt.Errorf("connection refused"))
+	}
+	if stats := b.Stats(); stats.State != BreakerOpen {
+		t.Fatalf("expect open, got %+v", stats)
+	}
+	time.Sleep(60 * time.Millisecond)
+	probe, err := b.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 打开前放行的回源在半开期间成功返回, 不能当作探测结果
+	b.done(slow, nil)
+	if stats := b.Stats(); stats.State != BreakerHalfOpen {
+		t.Fatalf("stale result closed the breaker: %+v", stats)
+	}
+	b.done(probe, nil)
+	if stats := b.Stats(); stats.State != BreakerClosed {
+		t.Fatalf("expect closed, got %+v", stats)
+	}
+}
+
+// TestGroups 各缓存组有独立的容量、淘汰策略与 Retriever
+func TestGroups(t *testing.T) {
+	users := NewGroup("users", 2<<10, "lru", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			return []byte("user:" + key), nil
+		}))
+	orders := NewGroup("orders", 0, "lfu", RetrieverFunc(
+		func(key string) ([]byte, error) {
+			return []byte("order:" + key), nil
+		}))
+	t.Cleanup(func() {
+		for _, name := range []string{"users", "orders"} {
+			if err := RemoveGroup(context.Background(), name); err != nil {
+				t.Error(err)
+			}
+		}
+		if GetGroup("users") != nil || GetGroup("orders") != nil {
+			t.Errorf("groups not removed")
+		}
+	})
+	if GetGroup("users") != users || GetGroup("orders") != orders || GetGroup("missing") != nil {
+		t.Fatalf("get group failed")
+	}
+	if v, err := users.Get("1"); err != nil || v.String() != "user:1" {
+		t.Fatalf("unexpected users value %v %v", v, err)
+	}
+	if v, err := orders.Get("1"); err != nil || v.String() != "order:1" {
+		t.Fatalf("unexpected orders value %v %v", v, err)
+	}
+	orders.Set("2", ByteView{[]byte("v")}, 10)
+	if users.TTL("2") != -2 || orders.TTL("2") <= 0 {
+		t.Fatalf("ttl should be scoped per group")
+	}
+	if len(users.GetAll()) != 1 || len(orders.GetAll()) != 2 {
+		t.Fatalf("getall should be scoped per group")
+	}
+	if users.Strategy() != "lru" || orders.Strategy() != "lfu" || users.Stats().MaxBytes != 2<<10 {
+		t.Fatalf("unexpected group config")
+	}
+	if users.cache.backup == orders.cache.backup {
+		t.Fatalf("groups should use separate backup files")
+	}
+	names := Groups()
+	if i := sort.SearchStrings(names, "orders"); i == len(names) || names[i] != "orders" {
+		t.Fatalf("orders not registered, got %v", names)
+	}
+	// 替换默认组时原有实例被停止
+	retriever := RetrieverFunc(func(key string) ([]byte, error) { return []byte(key), nil })
+	old := NewSaberCache(2<<10, "lru", retriever)
+	if NewSaberCache(2<<10, "lru", retriever) != GetGroup(DefaultGroup) {
+		t.Fatalf("default group not replaced")
+	}
+	select {
+	case <-old.cache.stop:
+	default:
+		t.Fatalf("replaced default group not stopped")
+	}
+	// 先替换默认组再 Close 原有实例, Close 阻塞期间默认组已是新实例
+	w := newMemWriter()
+	w.gate = make(chan struct{})
+	old = NewSaberCache(2<<10, "lru", retriever, WithWriteBehind(w, 4, 0, time.Millisecond))
+	old.Set("k", ByteView{[]byte("v")}, -1)
+	replaced := make(chan *SaberCache)
+	go func() { replaced <- NewSaberCache(2<<10, "lru", retriever) }()
+	deadline := time.Now().Add(time.Second)
+	for GetGroup(DefaultGroup) == old && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	current := GetGroup(DefaultGroup)
+	close(w.gate)
+	if sc := <-replaced; current != sc {
+		t.Fatalf("default group not replaced before closing the old instance")
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("duplicate group should panic")
+		}
+	}()
+	NewGroup("users", 0, "lru", RetrieverFunc(func(key string) ([]byte, error) { return nil, nil }))
+}
+
+// TestGroupName 名称会用于备份文件名, 不合法的名称 panic
+func TestGroupName(t *testing.T) {
+	for _, name := range []string{"../etc", "a/b", "a.b", "a b", "组"} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("group name %q should panic", name)
+				}
+			}()
+			NewGroup(name, 0, "lru", RetrieverFunc(func(key string) ([]byte, error) { return nil, nil }))
+		}()
+		if GetGroup(name) != nil {
+			t.Errorf("invalid group %q registered", name)
+		}
+	}
+}
+
+// TestNamespaces 命名空间在各自的配额内淘汰, 回源时传入不带前缀的Key与命名空间
+func TestNamespaces(t *testing.T) {
+	var mu sync.Mutex
+	retrieved := make(map[string]string)
+	sc := NewGroup("tenants", 300, "lru", ContextRetrieverFunc(
+		func(ctx context.Context, key string) ([]byte, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			retrieved[key] = NamespaceFromContext(ctx)
+			return []byte("value"), nil
+		}), WithNamespaces(map[string]int64{"bulk": 100}))
+	t.Cleanup(func() {
+		for _, name := range []string{"tenants", "plain"} {
+			if err := RemoveGroup(context.Background(), name); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+	for i := 0; i < 10; i++ {
+		sc.Set("key"+strconv.Itoa(i), ByteView{[]byte("value")}, -1)
+	}
+	if v, err := sc.Get(cachememory.NamespaceKey("bulk", "k")); err != nil || v.String() != "value" {
+		t.Fatalf("unexpected value %v %v", v, err)
+	}
+	mu.Lock()
+	if ns, ok := retrieved["k"]; !ok || ns != "bulk" {
+		t.Fatalf("retriever should receive raw key and namespace, got %v", retrieved)
+	}
+	mu.Unlock()
+	for i := 0; i < 100; i++ {
+		sc.Set(cachememory.NamespaceKey("bulk", "key"+strconv.Itoa(i)), ByteView{[]byte("value")}, -1)
+	}
+	stats := sc.NamespaceStats()
+	if stats[""].Entries != 10 || stats["bulk"].Bytes > 100 || stats["bulk"].Evictions == 0 || stats[""].Evictions != 0 {
+		t.Fatalf("bulk load should only evict its own keys, got %+v", stats)
+	}
+	if !sc.HasNamespace("bulk") || sc.HasNamespace("other") {
+		t.Fatalf("has namespace failed")
+	}
+	// 运行中新增命名空间, 已有的Key保留
+	if err := sc.SetNamespaceQuota("other", 300); err == nil {
+		t.Fatalf("quota over capacity should fail")
+	}
+	if err := sc.SetNamespaceQuota("other", 50); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := sc.cache.Get("key0"); !ok || sc.NamespaceStats()["other"].MaxBytes != 50 {
+		t.Fatalf("set quota failed, %+v", sc.NamespaceStats())
+	}
+	// 切换淘汰策略后命名空间与配额保持不变
+	if err := sc.SetStrategy("fifo"); err != nil {
+		t.Fatal(err)
+	}
+	if stats := sc.NamespaceStats(); stats["bulk"].MaxBytes != 100 || stats[""].Entries != 10 {
+		t.Fatalf("namespaces lost after strategy switch, %+v", stats)
+	}
+
+	plain := NewGroup("plain", 0, "lru", RetrieverFunc(func(key string) ([]byte, error) { return nil, nil }))
+	plain.Set("k", ByteView{[]byte("v")}, -1)
+	if err := plain.SetNamespaceQuota("team", 64); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := plain.Get("k"); err != nil || v.String() != "v" {
+		t.Fatalf("keys should be migrated, got %v", err)
+	}
+}
@@ -2,15 +2,19 @@ package sabercache_server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"sabercache_server/cachememory"
 	pb "sabercache_server/sabercachepb"
 	"sabercache_server/util"
 	"strings"
 	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type Server struct {
@@ -65,6 +69,29 @@ func (s *Server) Start() error {
 	return nil
 }
 
+// group 返回请求指定的缓存组, 未指定时使用默认组
+func (s *Server) group(name string) (*SaberCache, error) {
+	sc := GetGroup(name)
+	if sc == nil {
+		return nil, status.Errorf(codes.NotFound, "group %s not found", name)
+	}
+	return sc, nil
+}
+
+// checkNamespace 命名空间需在缓存组中注册, 为空时使用默认命名空间;
+// Key不能包含 cachememory.NamespaceSep, 否则可以越过命名空间读写其他命名空间的Key
+func checkNamespace(sc *SaberCache, ns string, keys ...string) error {
+	if !sc.HasNamespace(ns) {
+		return status.Errorf(codes.NotFound, "namespace %s not found in group %s", ns, sc.Name())
+	}
+	for _, key := range keys {
+		if strings.Contains(key, cachememory.NamespaceSep) {
+			return status.Errorf(codes.InvalidArgument, "key %q contains namespace separator", key)
+		}
+	}
+	return nil
+}
+
 func (s *Server) Get(ctx context.Context, in *pb.GetRequest) (*pb.GetResponse, error) {
 	key := in.GetKey()
 	resp := &pb.GetResponse{}
@@ -73,7 +100,24 @@ func (s *Server) Get(ctx context.Context, in *pb.GetRequest) (*pb.GetResponse, e
 	if key == "" {
 		return resp, fmt.Errorf("key required")
 	}
-	view, err := sabercache.Get(key)
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	if err := checkNamespace(sc, in.GetNamespace(), key); err != nil {
+		return resp, err
+	}
+	key = cachememory.NamespaceKey(in.GetNamespace(), key)
+	view, err := sc.GetContext(ctx, key)
+	if errors.Is(err, ErrNotFound) {
+		return resp, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return resp, status.Error(codes.Unavailable, err.Error())
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return resp, status.FromContextError(err).Err()
+	}
 	if err != nil {
 		return resp, err
 	}
@@ -81,13 +125,61 @@ func (s *Server) Get(ctx context.Context, in *pb.GetRequest) (*pb.GetResponse, e
 	return resp, nil
 }
 
+// MultiGet 批量获取, 未命中的Key合并回源
+func (s *Server) MultiGet(ctx context.Context, in *pb.MultiGetRequest) (*pb.MultiGetResponse, error) {
+	keys := in.GetKeys()
+	resp := &pb.MultiGetResponse{}
+	log.Printf("[sabercache_svr %s] Recv RPC Request - (%d keys)", s.addr, len(keys))
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	ns := in.GetNamespace()
+	if err := checkNamespace(sc, ns, keys...); err != nil {
+		return resp, err
+	}
+	keys = append([]string(nil), keys...)
+	for i, key := range keys {
+		keys[i] = cachememory.NamespaceKey(ns, key)
+	}
+	values, err := sc.GetMulti(ctx, keys)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return resp, status.FromContextError(err).Err()
+	}
+	if errors.Is(err, ErrCircuitOpen) {
+		return resp, status.Error(codes.Unavailable, err.Error())
+	}
+	if err != nil {
+		return resp, err
+	}
+	for key, view := range values {
+		_, key = cachememory.SplitNamespace(key)
+		resp.Kv = append(resp.Kv, &pb.KeyValue{Key: key, Value: view.ByteSlice()})
+	}
+	return resp, nil
+}
+
 func (s *Server) GetAll(ctx context.Context, in *pb.GetAllRequest) (*pb.GetAllResponse, error) {
 	resp := &pb.GetAllResponse{}
 
 	log.Printf("[sabercache_svr %s] Recv RPC Request", s.addr)
-	kv := sabercache.GetAll()
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	ns := in.GetNamespace()
+	if err := checkNamespace(sc, ns); err != nil {
+		return resp, err
+	}
+	kv := sc.GetAll()
 	for _, v := range kv {
-		resp.Kv = append(resp.Kv, &pb.KeyValue{Key: v.Key, Value: v.Value.(ByteView).ByteSlice()})
+		keyNs, key := cachememory.SplitNamespace(v.Key)
+		if keyNs != ns {
+			continue
+		}
+		if view, ok := v.Value.(ByteView); ok {
+			resp.Kv = append(resp.Kv, &pb.KeyValue{Key: key, Value: view.ByteSlice()})
+		}
 	}
 	return resp, nil
 }
@@ -99,7 +191,26 @@ func (s *Server) Set(ctx context.Context, in *pb.SetRequest) (*pb.SetResponse, e
 	if key == "" {
 		return resp, fmt.Errorf("key required")
 	}
-	resp.Ok = sabercache.Set(key, ByteView{value}, ttl)
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	if err := checkNamespace(sc, in.GetNamespace(), key); err != nil {
+		return resp, err
+	}
+	key = cachememory.NamespaceKey(in.GetNamespace(), key)
+	if in.GetSliding() && ttl != -1 {
+		if in.GetUnit() != pb.TimeUnit_MILLISECOND {
+			ttl *= 1000
+		}
+		resp.Ok = sc.SetSliding(key, ByteView{value}, ttl)
+		return resp, nil
+	}
+	if in.GetUnit() == pb.TimeUnit_MILLISECOND {
+		resp.Ok = sc.PSet(key, ByteView{value}, ttl)
+	} else {
+		resp.Ok = sc.Set(key, ByteView{value}, ttl)
+	}
 	return resp, nil
 }
 
@@ -110,12 +221,125 @@ func (s *Server) TTL(ctx context.Context, in *pb.TTLRequest) (*pb.TTLResponse, e
 	if key == "" {
 		return resp, fmt.Errorf("key required")
 	}
-	resp.Ttl = sabercache.TTL(key)
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	if err := checkNamespace(sc, in.GetNamespace(), key); err != nil {
+		return resp, err
+	}
+	key = cachememory.NamespaceKey(in.GetNamespace(), key)
+	if in.GetUnit() == pb.TimeUnit_MILLISECOND {
+		resp.Ttl = sc.PTTL(key)
+	} else {
+		resp.Ttl = sc.TTL(key)
+	}
+	if resp.Ttl != -2 {
+		return resp, nil
+	}
+	// Key不存在时返回负缓存的剩余时间
+	if in.GetUnit() == pb.TimeUnit_MILLISECOND {
+		resp.Ttl = sc.NegativePTTL(key)
+	} else {
+		resp.Ttl = sc.NegativeTTL(key)
+	}
+	resp.Negative = resp.Ttl != -2
 	return resp, nil
 }
 func (s *Server) Save(ctx context.Context, in *pb.SaveRequest) (*pb.SaveResponse, error) {
 	resp := &pb.SaveResponse{}
 	log.Printf("[sabercache_svr %s] Recv RPC Request save", s.addr)
-	resp.Ok = sabercache.Save()
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	resp.Ok = sc.Save()
+	return resp, nil
+}
+
+func (s *Server) Stats(ctx context.Context, in *pb.StatsRequest) (*pb.StatsResponse, error) {
+	log.Printf("[sabercache_svr %s] Recv RPC Request", s.addr)
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return &pb.StatsResponse{}, err
+	}
+	ns := in.GetNamespace()
+	if err := checkNamespace(sc, ns); err != nil {
+		return &pb.StatsResponse{}, err
+	}
+	stats, evictStats, loadStats, writeStats := sc.Stats(), sc.EvictStats(), sc.LoadStats(), sc.WriteStats()
+	breakerStats := sc.BreakerStats()
+	namespaces := make(map[string]*pb.NamespaceStats)
+	for name, st := range sc.NamespaceStats() {
+		if ns != "" && name != ns {
+			continue
+		}
+		namespaces[name] = &pb.NamespaceStats{Bytes: st.Bytes, Entries: int64(st.Entries), MaxBytes: st.MaxBytes, Evictions: st.Evictions}
+	}
+	return &pb.StatsResponse{
+		Group:           sc.Name(),
+		Bytes:           stats.Bytes,
+		Entries:         int64(stats.Entries),
+		MaxBytes:        stats.MaxBytes,
+		MaxEntries:      int64(stats.MaxEntries),
+		Strategy:        sc.Strategy(),
+		Overhead:        stats.Overhead,
+		Evictions:       evictStats.Evictions,
+		GhostHits:       evictStats.Ghost.Hits,
+		GhostHitBytes:   evictStats.Ghost.HitBytes,
+		StaleHits:       loadStats.StaleHits,
+		Refreshes:       loadStats.Refreshes,
+		RefreshFailures: loadStats.RefreshFailures,
+		NegativeHits:    loadStats.NegativeHits,
+		NegativeEntries: int64(loadStats.NegativeEntries),
+		WriteQueueDepth: int64(writeStats.QueueDepth),
+		WritesFlushed:   writeStats.Flushed,
+		WritesCoalesced: writeStats.Coalesced,
+		WriteRetries:    writeStats.Retries,
+		WriteFailures:   writeStats.Failures,
+		WritesRejected:  writeStats.Rejected,
+		BreakerState:    breakerStats.State.String(),
+		BreakerRequests: breakerStats.Requests,
+		BreakerFailures: breakerStats.Failures,
+		BreakerOpens:    breakerStats.Opens,
+		BreakerRejected: breakerStats.Rejected,
+		LoadRetries:     loadStats.Retries,
+		Namespaces:      namespaces,
+	}, nil
+}
+
+func (s *Server) Delete(ctx context.Context, in *pb.DeleteRequest) (*pb.DeleteResponse, error) {
+	key := in.GetKey()
+	resp := &pb.DeleteResponse{}
+	log.Printf("[sabercache_svr %s] Recv RPC Request - (%s)", s.addr, key)
+	if key == "" {
+		return resp, fmt.Errorf("key required")
+	}
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	if err := checkNamespace(sc, in.GetNamespace(), key); err != nil {
+		return resp, err
+	}
+	key = cachememory.NamespaceKey(in.GetNamespace(), key)
+	resp.Ok = sc.Delete(key)
+	return resp, nil
+}
+
+// SetStrategy 管理接口, 在线切换节点的淘汰策略
+func (s *Server) SetStrategy(ctx context.Context, in *pb.SetStrategyRequest) (*pb.SetStrategyResponse, error) {
+	strategy := in.GetStrategy()
+	resp := &pb.SetStrategyResponse{}
+	log.Printf("[sabercache_svr %s] Recv RPC Request - (%s)", s.addr, strategy)
+	sc, err := s.group(in.GetGroup())
+	if err != nil {
+		return resp, err
+	}
+	resp.Previous = sc.Strategy()
+	if err := sc.SetStrategy(strategy); err != nil {
+		return resp, err
+	}
+	resp.Ok = true
 	return resp, nil
 }
@@ -5,49 +5,96 @@ package main
 //CGO_ENABLED=0  GOOS=linux  GOARCH=amd64  go build main.go
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
 	"sabercache_server/util"
 	"strings"
+	"syscall"
+	"time"
 
 	"sabercache_server"
 )
 
 func main() {
+	opts := []sabercache_server.Option{
+		sabercache_server.WithLoadTTL(time.Duration(util.LoadTTL)*time.Millisecond, time.Duration(util.LoadTTLJitter)*time.Millisecond),
+		sabercache_server.WithLoadTimeout(time.Duration(util.LoadTimeout) * time.Millisecond),
+		sabercache_server.WithWriteTimeout(time.Duration(util.WriteTimeout) * time.Millisecond),
+		sabercache_server.WithStaleWhileRevalidate(time.Duration(util.StaleWhileRevalidate) * time.Millisecond),
+		sabercache_server.WithNegativeCache(time.Duration(util.NegativeTTL)*time.Millisecond, util.NegativeEntries),
+		sabercache_server.WithCircuitBreaker(util.BreakerFailureRate, util.BreakerMinRequests,
+			time.Duration(util.BreakerWindow)*time.Millisecond, time.Duration(util.BreakerOpenInterval)*time.Millisecond, util.BreakerHalfOpenProbes),
+		sabercache_server.WithRetry(util.RetrieveRetries,
+			time.Duration(util.RetrieveBackoff)*time.Millisecond, time.Duration(util.RetrieveMaxBackoff)*time.Millisecond),
+	}
 	// 新建cache实例
-	sc := sabercache_server.NewSaberCache(2<<10, util.CacheStrategy, sabercache_server.RetrieverFunc(
-		func(key string) ([]byte, error) {
-			log.Println("[Mysql] search key", key)
-			file, err := os.OpenFile("../file/mysql.txt", os.O_RDONLY, 0777)
-			if err != nil {
-				return []byte{}, err
-			}
-			reader := bufio.NewReader(file)
-			for {
-				bytes, _, err := reader.ReadLine()
-				if err == io.EOF {
-					return []byte{}, fmt.Errorf("not found the key:%s", key)
-				} else if err != nil {
-					return []byte{}, err
-				}
-				kv := strings.Split(string(bytes), " ")
-				if kv[0] == key {
-					return []byte(kv[1]), nil
-				}
-			}
-		}))
+	sc := sabercache_server.NewGroup(sabercache_server.DefaultGroup, util.MaxBytes, util.CacheStrategy, mysqlRetriever(sabercache_server.DefaultGroup),
+		append(opts, sabercache_server.WithNamespaces(util.Quotas(util.Namespaces)))...)
+	groups := []*sabercache_server.SaberCache{sc}
+	for _, g := range util.Groups {
+		strategy := g.CacheStrategy
+		if strategy == "" {
+			strategy = util.CacheStrategy
+		}
+		groups = append(groups, sabercache_server.NewGroup(g.Name, g.MaxBytes, strategy, mysqlRetriever(g.Name),
+			append(opts, sabercache_server.WithNamespaces(util.Quotas(g.Namespaces)))...))
+	}
 	// New一个服务实例
 	svr, err := sabercache_server.NewServer()
 	if err != nil {
 		log.Fatal(err)
 	}
 	sc.RegisterSvr(svr)
-	log.Println("sabercache is running at", util.RPCAddr, "cache Strategy:", util.CacheStrategy)
+	// 退出前写完 write-behind 队列中的数据
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, syscall.SIGINT, syscall.SIGTERM)
+		<-sig
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		for _, g := range groups {
+			if err := g.Close(ctx); err != nil {
+				log.Println("flush write-behind queue of group", g.Name(), "failed:", err)
+			}
+		}
+		os.Exit(0)
+	}()
+	log.Println("sabercache is running at", util.RPCAddr, "cache Strategy:", util.CacheStrategy, "groups:", sabercache_server.Groups())
 	// Start将不会return 除非服务stop或者抛出error
 	err = svr.Start()
 	if err != nil {
 		log.Fatal(err)
 	}
 }
+
+// mysqlRetriever 缓存组 group 的数据源
+func mysqlRetriever(group string) sabercache_server.Retriever {
+	return sabercache_server.ContextRetrieverFunc(func(ctx context.Context, key string) ([]byte, error) {
+		log.Println("[Mysql] search key", key, "group", group, "namespace", sabercache_server.NamespaceFromContext(ctx))
+		file, err := os.OpenFile("../file/mysql.txt", os.O_RDONLY, 0777)
+		if err != nil {
+			return []byte{}, err
+		}
+		defer file.Close()
+		reader := bufio.NewReader(file)
+		for {
+			if err := ctx.Err(); err != nil {
+				return []byte{}, err
+			}
+			bytes, _, err := reader.ReadLine()
+			if err == io.EOF {
+				return []byte{}, fmt.Errorf("%w: %s", sabercache_server.ErrNotFound, key)
+			} else if err != nil {
+				return []byte{}, err
+			}
+			kv := strings.Split(string(bytes), " ")
+			if kv[0] == key {
+				return []byte(kv[1]), nil
+			}
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package sabercache_server
+
+import (
+	"context"
+	pb "sabercache_server/sabercachepb"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestServerNamespaceSep 默认命名空间的请求不能通过带分隔符的Key读写其他命名空间
+func TestServerNamespaceSep(t *testing.T) {
+	NewGroup("isolated", 300, "lru", RetrieverFunc(func(key string) ([]byte, error) {
+		return []byte("origin"), nil
+	}), WithNamespaces(map[string]int64{"bulk": 100}))
+	t.Cleanup(func() {
+		if err := RemoveGroup(context.Background(), "isolated"); err != nil {
+			t.Error(err)
+		}
+	})
+	s := &Server{}
+	ctx := context.Background()
+	if resp, err := s.Set(ctx, &pb.SetRequest{Group: "isolated", Namespace: "bulk", Key: "foo", Value: []byte("secret"), Ttl: -1}); err != nil || !resp.Ok {
+		t.Fatalf("set failed %v", err)
+	}
+	key := "bulk\x00foo"
+	invalid := func(name string, err error) {
+		t.Helper()
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%s: expect InvalidArgument, got %v", name, err)
+		}
+	}
+	_, err := s.Get(ctx, &pb.GetRequest{Group: "isolated", Key: key})
+	invalid("get", err)
+	_, err = s.MultiGet(ctx, &pb.MultiGetRequest{Group: "isolated", Keys: []string{"other", key}})
+	invalid("mget", err)
+	_, err = s.Set(ctx, &pb.SetRequest{Group: "isolated", Key: key, Value: []byte("overwrite"), Ttl: -1})
+	invalid("set", err)
+	_, err = s.TTL(ctx, &pb.TTLRequest{Group: "isolated", Key: key})
+	invalid("ttl", err)
+	_, err = s.Delete(ctx, &pb.DeleteRequest{Group: "isolated", Key: key})
+	invalid("delete", err)
+	resp, err := s.Get(ctx, &pb.GetRequest{Group: "isolated", Namespace: "bulk", Key: "foo"})
+	if err != nil || string(resp.Value) != "secret" {
+		t.Fatalf("bulk value changed: %v %v", resp, err)
+	}
+}
@@ -0,0 +1,183 @@
+// simulator 按访问序列回放各淘汰策略, 输出不同容量下的命中率、字节命中率与淘汰次数,
+// 直接使用 cachememory 中的实现, 用于为实际流量选择 CacheStrategy。
+//
+//	cd sabercache_server && go run ./simulator --trace cachememory/testdata/zipf.trace
+//	go run ./simulator --trace OLTP.lis --format arc --size 512 --capacities 1%,10%,65536
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sabercache_server/cachememory"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// size 只记录大小的 Value
+type size int
+
+func (s size) Len() int {
+	return int(s)
+}
+
+var resetPeriod = flag.Int64("tinylfu-reset", 10000, "W-TinyLFU reset period")
+
+var policies = map[string]func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory{
+	"lru": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewLRUCache(maxBytes, callback)
+	},
+	"lfu": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewLFUCache(maxBytes, callback)
+	},
+	"fifo": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewFIFOCache(maxBytes, callback)
+	},
+	"arc": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewARCCache(maxBytes, callback)
+	},
+	"tinylfu": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewTinyLFUCache(maxBytes, *resetPeriod, callback)
+	},
+	"gds": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewGDSCache(maxBytes, callback)
+	},
+	"clock": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewClockCache(maxBytes, callback)
+	},
+	"s3fifo": func(maxBytes int64, callback cachememory.OnEliminated) cachememory.CacheMemory {
+		return cachememory.NewS3FIFOCache(maxBytes, callback)
+	},
+}
+
+// result 一次回放的结果
+type result struct {
+	requests  int
+	hits      int
+	bytes     int64 // 请求的总字节数
+	hitBytes  int64
+	evictions int
+}
+
+func (r result) hitRatio() float64 {
+	return float64(r.hits) / float64(r.requests)
+}
+
+func (r result) byteHitRatio() float64 {
+	return float64(r.hitBytes) / float64(r.bytes)
+}
+
+// simulate 按顺序回放请求, 未命中时写入缓存
+func simulate(policy string, reqs []request, capacity int64) (r result) {
+	cache := policies[policy](capacity, func(key string, value cachememory.Value, reason cachememory.EvictReason) {
+		if reason == cachememory.EvictCapacity {
+			r.evictions++
+		}
+	})
+	defer cache.Stop()
+	for _, req := range reqs {
+		r.requests++
+		r.bytes += int64(req.size)
+		if _, ok := cache.Get(req.key); ok {
+			r.hits++
+			r.hitBytes += int64(req.size)
+		} else {
+			cache.SetWithoutTTL(req.key, size(req.size))
+		}
+	}
+	return
+}
+
+// parseCapacities 解析容量列表, 以 % 结尾的按总字节数的百分比计算, 不足1字节时取1字节;
+// 容量为0在 CacheMemory 中表示不限制, 因此容量必须为正数
+func parseCapacities(s string, total int64) ([]int64, error) {
+	var capacities []int64
+	for _, c := range strings.Split(s, ",") {
+		c = strings.TrimSpace(c)
+		if strings.HasSuffix(c, "%") {
+			percent, err := strconv.ParseFloat(strings.TrimSuffix(c, "%"), 64)
+			if err != nil || percent <= 0 {
+				return nil, fmt.Errorf("invalid capacity %q", c)
+			}
+			capacity := int64(float64(total) * percent / 100)
+			if capacity < 1 {
+				capacity = 1
+			}
+			capacities = append(capacities, capacity)
+			continue
+		}
+		capacity, err := strconv.ParseInt(c, 10, 64)
+		if err != nil || capacity <= 0 {
+			return nil, fmt.Errorf("invalid capacity %q", c)
+		}
+		capacities = append(capacities, capacity)
+	}
+	return capacities, nil
+}
+
+// parsePolicies 解析淘汰策略列表, all 表示全部
+func parsePolicies(s string) ([]string, error) {
+	var names []string
+	if s == "all" {
+		for name := range policies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names, nil
+	}
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := policies[name]; !ok {
+			return nil, fmt.Errorf("unknown policy %q", name)
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func main() {
+	var (
+		trace      = flag.String("trace", "", "access trace file")
+		format     = flag.String("format", "saber", "trace format: saber, arc, lirs")
+		blockSize  = flag.Int("size", 64, "value size in bytes when the trace does not record one")
+		capacities = flag.String("capacities", "1%,5%,10%,25%", "comma separated capacities in bytes, or percent of the trace footprint")
+		policyList = flag.String("policies", "all", "comma separated policies, or all")
+	)
+	flag.Parse()
+	parse, ok := parsers[*format]
+	if !ok || *trace == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	reqs, err := readTrace(*trace, parse, *blockSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(reqs) == 0 {
+		log.Fatalf("%s: no requests", *trace)
+	}
+	total := footprint(reqs)
+	caps, err := parseCapacities(*capacities, total)
+	if err != nil {
+		log.Fatal(err)
+	}
+	names, err := parsePolicies(*policyList)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Printf("%s: %d requests, footprint %d bytes\n", *trace, len(reqs), total)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "capacity\tpolicy\thit ratio\tbyte hit ratio\tevictions\t")
+	for _, capacity := range caps {
+		for _, name := range names {
+			r := simulate(name, reqs, capacity)
+			fmt.Fprintf(w, "%d\t%s\t%.2f%%\t%.2f%%\t%d\t\n",
+				capacity, name, r.hitRatio()*100, r.byteHitRatio()*100, r.evictions)
+		}
+	}
+	w.Flush()
+}
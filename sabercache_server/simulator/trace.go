@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// request 一次访问, size 为 Value 的字节数
+type request struct {
+	key  string
+	size int
+}
+
+// parser 将访问序列解析为请求, size 为未记录大小时 Value 的默认字节数
+type parser func(r io.Reader, size int) ([]request, error)
+
+var parsers = map[string]parser{
+	"saber": parseSaber,
+	"arc":   parseARC,
+	"lirs":  parseLIRS,
+}
+
+// readTrace 读取并解析访问序列文件
+func readTrace(path string, parse parser, size int) ([]request, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	reqs, err := parse(file, size)
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %v", path, err)
+	}
+	return reqs, nil
+}
+
+// parseSaber 本项目的访问序列格式, 每行 "key [size]", # 开头的行为注释,
+// 与 cachememory/testdata 下的访问序列相同
+func parseSaber(r io.Reader, size int) (reqs []request, err error) {
+	err = scanLines(r, func(n int, line string) error {
+		if strings.HasPrefix(line, "#") {
+			return nil
+		}
+		fields := strings.Fields(line)
+		req := request{key: fields[0], size: size}
+		if len(fields) > 1 {
+			s, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return fmt.Errorf("line %d: invalid size %q", n, fields[1])
+			}
+			req.size = s
+		}
+		reqs = append(reqs, req)
+		return nil
+	})
+	return
+}
+
+// parseARC ARC论文(Megiddo & Modha)使用的访问序列格式,
+// 每行 "起始块号 块数 忽略 请求序号", 展开为连续块的访问, 每块 size 字节
+func parseARC(r io.Reader, size int) (reqs []request, err error) {
+	err = scanLines(r, func(n int, line string) error {
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			return fmt.Errorf("line %d: expect at least 2 fields", n)
+		}
+		start, err := strconv.ParseInt(fields[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid start block %q", n, fields[0])
+		}
+		blocks, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("line %d: invalid block count %q", n, fields[1])
+		}
+		for i := int64(0); i < blocks; i++ {
+			reqs = append(reqs, request{key: strconv.FormatInt(start+i, 10), size: size})
+		}
+		return nil
+	})
+	return
+}
+
+// parseLIRS LIRS论文使用的访问序列格式, 每行一个块号, 非数字的行(如 *)作为分隔符忽略
+func parseLIRS(r io.Reader, size int) (reqs []request, err error) {
+	err = scanLines(r, func(n int, line string) error {
+		if _, err := strconv.ParseInt(line, 10, 64); err != nil {
+			return nil
+		}
+		reqs = append(reqs, request{key: line, size: size})
+		return nil
+	})
+	return
+}
+
+// scanLines 逐行读取, 跳过空行, n 为行号
+func scanLines(r io.Reader, fn func(n int, line string) error) error {
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if err := fn(n, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// footprint 所有Key与 Value 的总字节数, 即容纳全部访问所需的容量
+func footprint(reqs []request) (total int64) {
+	seen := make(map[string]bool)
+	for _, req := range reqs {
+		if !seen[req.key] {
+			seen[req.key] = true
+			total += int64(len(req.key) + req.size)
+		}
+	}
+	return
+}
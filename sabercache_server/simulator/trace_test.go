@@ -0,0 +1,72 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		format string
+		input  string
+		expect []request
+	}{
+		{"saber", "# key size\nk1 10\n\nk2\n", []request{{"k1", 10}, {"k2", 64}}},
+		{"arc", "100 3 0 1\n7 1 0 2\n", []request{{"100", 64}, {"101", 64}, {"102", 64}, {"7", 64}}},
+		{"lirs", "5\n*\n6\n5\n", []request{{"5", 64}, {"6", 64}, {"5", 64}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			reqs, err := parsers[tt.format](strings.NewReader(tt.input), 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.expect, reqs) {
+				t.Fatalf("expect %v, got %v", tt.expect, reqs)
+			}
+		})
+	}
+	if _, err := parsers["saber"](strings.NewReader("k1 x\n"), 64); err == nil {
+		t.Fatalf("invalid size accepted")
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	reqs := []request{{"k1", 10}, {"k2", 10}, {"k1", 10}, {"k3", 30}, {"k1", 10}}
+	for name := range policies {
+		r := simulate(name, reqs, 1000)
+		if r.hits != 2 || r.hitBytes != 20 || r.bytes != 70 || r.evictions != 0 {
+			t.Fatalf("%s: unexpected result %+v", name, r)
+		}
+	}
+	if caps, err := parseCapacities("10%,500", 1000); err != nil || !reflect.DeepEqual(caps, []int64{100, 500}) {
+		t.Fatalf("unexpected capacities %v %v", caps, err)
+	}
+	if caps, err := parseCapacities("0.01%", 1000); err != nil || !reflect.DeepEqual(caps, []int64{1}) {
+		t.Fatalf("small percent should be at least 1 byte, got %v %v", caps, err)
+	}
+	for _, s := range []string{"0", "-100", "0%", "-5%"} {
+		if _, err := parseCapacities(s, 1000); err == nil {
+			t.Fatalf("capacity %s accepted", s)
+		}
+	}
+}
+
+// TestReplayTrace 回放与 cachememory 基准测试共用的访问序列
+func TestReplayTrace(t *testing.T) {
+	reqs, err := readTrace("../cachememory/testdata/zipf.trace", parseSaber, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reqs) != 3000 {
+		t.Fatalf("expect 3000 requests, got %d", len(reqs))
+	}
+	capacity := footprint(reqs) / 10
+	fifo := simulate("fifo", reqs, capacity)
+	for _, name := range []string{"clock", "s3fifo"} {
+		if r := simulate(name, reqs, capacity); r.hits < fifo.hits || r.evictions == 0 {
+			t.Fatalf("%s: unexpected result %+v, fifo %+v", name, r, fifo)
+		}
+	}
+}
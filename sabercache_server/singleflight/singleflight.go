@@ -1,41 +1,201 @@
 package singleflight
 
 import (
+	"context"
+	"fmt"
+	"runtime/debug"
 	"sync"
 )
 
 type packet struct {
-	wg  sync.WaitGroup
-	val any
-	err error
+	done  chan struct{}
+	val   any
+	err   error
+	panic *PanicError // fn panic 时不为 nil
+	dups  int         // 加入等待的调用方数量, 大于0表示结果被共享
 }
 type Flight struct {
 	mu     sync.Mutex
 	flight map[string]*packet
 }
 
-func (f *Flight) Fly(key string, fn func() (any, error)) (any, error) {
+// PanicError fn panic 时, 所有等待该Key的调用方都会以 *PanicError 重新 panic
+type PanicError struct {
+	Value any    // recover 得到的值
+	Stack []byte // panic 时 fn 所在协程的调用栈
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("singleflight: fn panicked: %v\n\n%s", p.Value, p.Stack)
+}
+
+// Result 单个Key的加载结果, Shared 表示结果同时返回给了多个调用方
+type Result struct {
+	Val    any
+	Err    error
+	Shared bool
+}
+
+// Fly 同一Key同时只执行一次 fn, 其余调用方等待并共享结果; shared 表示结果同时返回给了多个调用方。
+// fn panic 时所有调用方都会 panic
+func (f *Flight) Fly(key string, fn func() (any, error)) (v any, err error, shared bool) {
+	p, leader := f.join(key)
+	if leader {
+		f.run(key, p, fn)
+	} else {
+		<-p.done
+	}
+	return p.result()
+}
+
+// FlyContext 与 Fly 相同, 但 fn 在单独的协程中执行, 调用方(包括发起加载的调用方)可以在自己的 ctx 结束时
+// 放弃等待并返回 ctx.Err(), fn 继续执行, 结果供仍在等待的调用方使用
+func (f *Flight) FlyContext(ctx context.Context, key string, fn func() (any, error)) (v any, err error, shared bool) {
+	p, leader := f.join(key)
+	if leader {
+		go f.run(key, p, fn)
+	}
+	select {
+	case <-p.done:
+		return p.result()
+	case <-ctx.Done():
+		return nil, ctx.Err(), false
+	}
+}
+
+// FlyChan 与 Fly 相同, 但立即返回, 结果就绪后写入返回的 channel;
+// fn panic 时不会 panic, 而是以 *PanicError 作为 Result.Err 返回
+func (f *Flight) FlyChan(key string, fn func() (any, error)) <-chan Result {
+	ch := make(chan Result, 1)
+	p, leader := f.join(key)
+	if leader {
+		go f.run(key, p, fn)
+	}
+	go func() {
+		<-p.done
+		if p.panic != nil {
+			ch <- Result{Err: p.panic, Shared: p.dups > 0}
+			return
+		}
+		ch <- Result{Val: p.val, Err: p.err, Shared: p.dups > 0}
+	}()
+	return ch
+}
+
+// FlyMulti 批量加载多个Key: 已在加载中的Key等待原有任务, 其余Key合并为一次 fn 调用,
+// fn 在单独的协程中执行, 需为传入的每个Key返回结果; ctx 结束时尚未完成的Key返回 ctx.Err()。
+// fn panic 时调用方 panic
+func (f *Flight) FlyMulti(ctx context.Context, keys []string, fn func(keys []string) map[string]Result) map[string]Result {
+	packets := make(map[string]*packet, len(keys))
+	var own []string
 	f.mu.Lock()
 	if f.flight == nil {
 		f.flight = make(map[string]*packet)
 	}
-	if p, ok := f.flight[key]; ok {
-		f.mu.Unlock()
-		p.wg.Wait()
-		return p.val, p.err
+	for _, key := range keys {
+		if _, ok := packets[key]; ok {
+			continue
+		}
+		if p, ok := f.flight[key]; ok {
+			p.dups++
+			packets[key] = p
+			continue
+		}
+		p := &packet{done: make(chan struct{})}
+		f.flight[key] = p
+		packets[key] = p
+		own = append(own, key)
 	}
-	p := new(packet)
-	p.wg.Add(1)
-	f.flight[key] = p
 	f.mu.Unlock()
 
-	p.val, p.err = fn()
-	p.wg.Done()
+	if len(own) > 0 {
+		go func() {
+			var results map[string]Result
+			pe := capture(func() {
+				results = fn(own)
+			})
+			f.mu.Lock()
+			for _, key := range own {
+				if f.flight[key] == packets[key] {
+					delete(f.flight, key)
+				}
+			}
+			f.mu.Unlock()
+			for _, key := range own {
+				p := packets[key]
+				p.val, p.err, p.panic = results[key].Val, results[key].Err, pe
+				close(p.done)
+			}
+		}()
+	}
+
+	results := make(map[string]Result, len(packets))
+	for key, p := range packets {
+		select {
+		case <-p.done:
+			v, err, shared := p.result()
+			results[key] = Result{Val: v, Err: err, Shared: shared}
+		case <-ctx.Done():
+			results[key] = Result{Err: ctx.Err()}
+		}
+	}
+	return results
+}
 
+// Forget 忘记Key的加载任务, 之后的调用会重新执行 fn, 已在等待的调用方仍得到原有结果
+func (f *Flight) Forget(key string) {
 	f.mu.Lock()
+	defer f.mu.Unlock()
 	delete(f.flight, key)
+}
+
+// join 返回Key对应的加载任务, leader 为 true 时由调用方负责执行
+func (f *Flight) join(key string) (p *packet, leader bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.flight == nil {
+		f.flight = make(map[string]*packet)
+	}
+	if p, ok := f.flight[key]; ok {
+		p.dups++
+		return p, false
+	}
+	p = &packet{done: make(chan struct{})}
+	f.flight[key] = p
+	return p, true
+}
+
+// run 执行 fn, 无论 fn 是否 panic 都会移除加载任务并唤醒等待者
+func (f *Flight) run(key string, p *packet, fn func() (any, error)) {
+	p.panic = capture(func() {
+		p.val, p.err = fn()
+	})
+
+	f.mu.Lock()
+	// Forget 之后可能已有新的加载任务, 不能将其移除
+	if f.flight[key] == p {
+		delete(f.flight, key)
+	}
 	f.mu.Unlock()
 
-	return p.val, p.err
+	close(p.done)
+}
+
+// result 在 done 关闭后读取结果, fn panic 时重新 panic
+func (p *packet) result() (any, error, bool) {
+	if p.panic != nil {
+		panic(p.panic)
+	}
+	return p.val, p.err, p.dups > 0
+}
 
+// capture 执行 fn 并捕获 panic
+func capture(fn func()) (pe *PanicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			pe = &PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	fn()
+	return nil
 }
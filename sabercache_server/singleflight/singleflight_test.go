@@ -0,0 +1,197 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestFly 并发调用同一Key只执行一次 fn, 所有调用方得到相同结果且标记为共享
+func TestFly(t *testing.T) {
+	var f Flight
+	var calls int32
+	release := make(chan struct{})
+	fn := func() (any, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return "v", nil
+	}
+	var wg sync.WaitGroup
+	var shared int32
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err, s := f.Fly("key", fn)
+			if v != "v" || err != nil {
+				t.Errorf("unexpected result %v %v", v, err)
+			}
+			if s {
+				atomic.AddInt32(&shared, 1)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls != 1 || shared != 10 {
+		t.Fatalf("expect 1 call and 10 shared results, got %d calls %d shared", calls, shared)
+	}
+	// 单独调用不共享
+	if _, _, s := f.Fly("key", func() (any, error) { return nil, nil }); s {
+		t.Fatalf("single caller should not be shared")
+	}
+}
+
+// TestFlyPanic fn panic 时所有等待者都以 *PanicError panic, 且Key不会残留
+func TestFlyPanic(t *testing.T) {
+	var f Flight
+	release := make(chan struct{})
+	fn := func() (any, error) {
+		<-release
+		panic("boom")
+	}
+	var wg sync.WaitGroup
+	var panics int32
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if pe, ok := recover().(*PanicError); ok && pe.Value == "boom" {
+					atomic.AddInt32(&panics, 1)
+				}
+			}()
+			f.Fly("key", fn)
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if panics != 5 {
+		t.Fatalf("expect 5 panics, got %d", panics)
+	}
+	if v, err, _ := f.Fly("key", func() (any, error) { return "v", nil }); v != "v" || err != nil {
+		t.Fatalf("key not released after panic: %v %v", v, err)
+	}
+}
+
+// TestForget Forget 之后的调用重新执行 fn, 原有等待者仍得到原有结果
+func TestForget(t *testing.T) {
+	var f Flight
+	release := make(chan struct{})
+	first := f.FlyChan("key", func() (any, error) {
+		<-release
+		return "old", nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	f.Forget("key")
+	v, err, _ := f.Fly("key", func() (any, error) { return "new", nil })
+	if v != "new" || err != nil {
+		t.Fatalf("expect new, got %v %v", v, err)
+	}
+	// 旧任务结束时不能移除新任务
+	release2 := make(chan struct{})
+	second := f.FlyChan("key", func() (any, error) {
+		<-release2
+		return "second", nil
+	})
+	close(release)
+	if r := <-first; r.Val != "old" {
+		t.Fatalf("expect old, got %v", r.Val)
+	}
+	third := f.FlyChan("key", func() (any, error) { return "third", nil })
+	close(release2)
+	if r := <-second; r.Val != "second" || !r.Shared {
+		t.Fatalf("unexpected %+v", r)
+	}
+	if r := <-third; r.Val != "second" || !r.Shared {
+		t.Fatalf("expect joining second, got %+v", r)
+	}
+}
+
+// TestFlyChan 异步等待结果, fn panic 时以 *PanicError 返回
+func TestFlyChan(t *testing.T) {
+	var f Flight
+	errBoom := errors.New("boom")
+	if r := <-f.FlyChan("err", func() (any, error) { return nil, errBoom }); r.Err != errBoom || r.Shared {
+		t.Fatalf("unexpected %+v", r)
+	}
+	r := <-f.FlyChan("panic", func() (any, error) { panic("boom") })
+	var pe *PanicError
+	if !errors.As(r.Err, &pe) || pe.Value != "boom" || len(pe.Stack) == 0 {
+		t.Fatalf("expect panic error, got %+v", r)
+	}
+}
+
+// TestFlyContext 调用方 ctx 结束后放弃等待, fn 的结果仍返回给其他等待者
+func TestFlyContext(t *testing.T) {
+	var f Flight
+	release := make(chan struct{})
+	fn := func() (any, error) {
+		<-release
+		return "v", nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err, _ := f.FlyContext(ctx, "key", fn); err != context.DeadlineExceeded {
+		t.Fatalf("expect deadline exceeded, got %v", err)
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if v, err, shared := f.FlyContext(context.Background(), "key", fn); v != "v" || err != nil || !shared {
+			t.Errorf("unexpected %v %v %v", v, err, shared)
+		}
+	}()
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	<-done
+}
+
+// TestFlyMulti 批量加载与单Key加载共享进行中的任务, fn panic 时调用方 panic
+func TestFlyMulti(t *testing.T) {
+	var f Flight
+	release := make(chan struct{})
+	single := f.FlyChan("k0", func() (any, error) {
+		<-release
+		return "single", nil
+	})
+	time.Sleep(10 * time.Millisecond)
+	var loaded []string
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+	}()
+	results := f.FlyMulti(context.Background(), []string{"k0", "k1", "k2", "k1"}, func(keys []string) map[string]Result {
+		loaded = keys
+		results := make(map[string]Result, len(keys))
+		for i, key := range keys {
+			results[key] = Result{Val: strconv.Itoa(i)}
+		}
+		return results
+	})
+	if len(loaded) != 2 || len(results) != 3 {
+		t.Fatalf("unexpected loaded %v results %v", loaded, results)
+	}
+	if r := results["k0"]; r.Val != "single" || !r.Shared {
+		t.Fatalf("k0 should join in-flight load, got %+v", r)
+	}
+	if r := <-single; !r.Shared {
+		t.Fatalf("single caller should be shared, got %+v", r)
+	}
+
+	defer func() {
+		if _, ok := recover().(*PanicError); !ok {
+			t.Fatalf("expect panic error")
+		}
+		if v, _, _ := f.Fly("k3", func() (any, error) { return "v", nil }); v != "v" {
+			t.Fatalf("key not released after panic")
+		}
+	}()
+	f.FlyMulti(context.Background(), []string{"k3"}, func(keys []string) map[string]Result { panic("boom") })
+}
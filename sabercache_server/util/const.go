@@ -12,8 +12,83 @@ var (
 	DefaultEtcdConfig = clientv3.Config{}
 	RPCAddr           string
 	CacheStrategy     string
+	// MaxBytes 缓存容量上限(Byte), 0 表示不限制
+	MaxBytes int64
+	// MaxEntries 缓存Key数量上限, 0 表示不限制
+	MaxEntries int
+	// MemoryAccounting 容量统计方式, "data" 只统计Key与Value, "overhead" 额外计入每个Key的内部结构开销
+	MemoryAccounting string
+	// EntryOverhead overhead 模式下每个Key额外计入的字节数, 小于等于0时按淘汰策略估算
+	EntryOverhead int64
+	// HeapLimit Go堆内存水位(Byte), 超过时调低缓存容量上限触发淘汰, 0 表示不启用
+	HeapLimit int64
+	// GhostEntries 记录最近因容量不足被淘汰的Key数量, 用于估算增加内存后的命中次数, 0 表示不记录
+	GhostEntries int
+	// StaleWhileRevalidate 软过期窗口(毫秒), Key剩余有效期不足该值时先返回缓存值再后台刷新, 0 表示不启用
+	StaleWhileRevalidate int64
+	// NegativeTTL 数据源中不存在的Key的负缓存时间(毫秒), 期间不再回源, 0 表示不启用
+	NegativeTTL int64
+	// NegativeEntries 负缓存最多记录的Key数量, 0 表示不限制
+	NegativeEntries int
+	// LoadTTL 回源取回数据的默认过期时间(毫秒), 0 表示使用默认值, -1 表示永不过期
+	LoadTTL int64
+	// LoadTTLJitter 默认过期时间上追加的随机时长上限(毫秒), 避免大量Key同时过期
+	LoadTTLJitter int64
+	// LoadTimeout 一次回源(含重试)的超时时间(毫秒), 由等待同一Key的请求共享, 0 表示不限制
+	LoadTimeout int64
+	// WriteTimeout 每次写入数据源(含重试)的超时时间(毫秒), 0 表示不限制
+	WriteTimeout int64
+	// BreakerFailureRate 回源失败率达到该值(0~1)时打开熔断器, 0 表示不启用
+	BreakerFailureRate float64
+	// BreakerMinRequests 统计窗口内回源次数不少于该值时才计算失败率
+	BreakerMinRequests int
+	// BreakerWindow 失败率统计窗口(毫秒)
+	BreakerWindow int64
+	// BreakerOpenInterval 熔断器打开后进入半开状态前的等待时间(毫秒)
+	BreakerOpenInterval int64
+	// BreakerHalfOpenProbes 半开状态下放行的探测请求数, 全部成功后关闭熔断器
+	BreakerHalfOpenProbes int
+	// RetrieveRetries 回源失败的最大重试次数, 0 表示不重试
+	RetrieveRetries int
+	// RetrieveBackoff 首次重试前的等待时间(毫秒), 之后逐次翻倍
+	RetrieveBackoff int64
+	// RetrieveMaxBackoff 重试等待时间上限(毫秒)
+	RetrieveMaxBackoff int64
+	// TinyLFUResetPeriod W-TinyLFU频率统计的衰减周期(访问次数)
+	TinyLFUResetPeriod int64
+	// LFUDecayPeriod LFU访问次数减半周期(秒), 0 表示不衰减
+	LFUDecayPeriod int64
+	// Shards CacheMemory 分片数, 大于1时按Key哈希分片以降低锁竞争
+	Shards int
+	// Namespaces 默认组的命名空间, 每个命名空间有独立的字节配额
+	Namespaces []NamespaceConfig
+	// Groups 默认组之外的缓存组, 每个组有独立的容量与淘汰策略, 其余配置与默认组相同
+	Groups []GroupConfig
 )
 
+// GroupConfig 缓存组配置, CacheStrategy 为空时与默认组相同
+type GroupConfig struct {
+	Name          string
+	MaxBytes      int64
+	CacheStrategy string
+	Namespaces    []NamespaceConfig
+}
+
+// NamespaceConfig 命名空间配置, MaxBytes 为字节配额, 从所在缓存组的容量中划出
+type NamespaceConfig struct {
+	Name     string
+	MaxBytes int64
+}
+
+// Quotas 返回命名空间名称到字节配额的映射
+func Quotas(namespaces []NamespaceConfig) map[string]int64 {
+	quotas := make(map[string]int64, len(namespaces))
+	for _, ns := range namespaces {
+		quotas[ns.Name] = ns.MaxBytes
+	}
+	return quotas
+}
+
 func init() {
 	viper.SetConfigFile("../conf/conf.yaml") // 指定配置文件路径
 	err := viper.ReadInConfig()              // 读取配置信息
@@ -29,4 +104,34 @@ func init() {
 	}
 	RPCAddr = viper.GetString("RPCAddr")
 	CacheStrategy = viper.GetString("CacheStrategy")
+	MaxBytes = viper.GetInt64("MaxBytes")
+	MaxEntries = viper.GetInt("MaxEntries")
+	MemoryAccounting = viper.GetString("MemoryAccounting")
+	EntryOverhead = viper.GetInt64("EntryOverhead")
+	HeapLimit = viper.GetInt64("HeapLimit")
+	GhostEntries = viper.GetInt("GhostEntries")
+	StaleWhileRevalidate = viper.GetInt64("StaleWhileRevalidate")
+	NegativeTTL = viper.GetInt64("NegativeTTL")
+	NegativeEntries = viper.GetInt("NegativeEntries")
+	LoadTTL = viper.GetInt64("LoadTTL")
+	LoadTTLJitter = viper.GetInt64("LoadTTLJitter")
+	LoadTimeout = viper.GetInt64("LoadTimeout")
+	WriteTimeout = viper.GetInt64("WriteTimeout")
+	BreakerFailureRate = viper.GetFloat64("BreakerFailureRate")
+	BreakerMinRequests = viper.GetInt("BreakerMinRequests")
+	BreakerWindow = viper.GetInt64("BreakerWindow")
+	BreakerOpenInterval = viper.GetInt64("BreakerOpenInterval")
+	BreakerHalfOpenProbes = viper.GetInt("BreakerHalfOpenProbes")
+	RetrieveRetries = viper.GetInt("RetrieveRetries")
+	RetrieveBackoff = viper.GetInt64("RetrieveBackoff")
+	RetrieveMaxBackoff = viper.GetInt64("RetrieveMaxBackoff")
+	TinyLFUResetPeriod = viper.GetInt64("TinyLFUResetPeriod")
+	LFUDecayPeriod = viper.GetInt64("LFUDecayPeriod")
+	Shards = viper.GetInt("Shards")
+	if err := viper.UnmarshalKey("Namespaces", &Namespaces); err != nil {
+		panic(fmt.Errorf("Fatal error config Namespaces: %s \n", err))
+	}
+	if err := viper.UnmarshalKey("Groups", &Groups); err != nil {
+		panic(fmt.Errorf("Fatal error config Groups: %s \n", err))
+	}
 }
@@ -0,0 +1,255 @@
+package sabercache_server
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrWriteQueueFull write-behind 队列已满, 写入被拒绝
+	ErrWriteQueueFull = errors.New("write-behind queue full")
+	// ErrWriterClosed Close 之后的写入被拒绝
+	ErrWriterClosed = errors.New("write-behind closed")
+)
+
+// DefaultWriteTimeout 未通过 WithWriteTimeout 配置时, 每次写入数据源的超时时间
+const DefaultWriteTimeout = 5 * time.Second
+
+// maxRetryInterval write-behind 重试间隔翻倍的上限
+const maxRetryInterval = 30 * time.Second
+
+// Writer 将 Set/Delete 同步到数据源
+type Writer interface {
+	Write(ctx context.Context, key string, value []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// WriteStats 写入数据源相关的统计
+type WriteStats struct {
+	QueueDepth int   // write-behind 队列中待写入的Key数量
+	Flushed    int64 // 成功写入数据源的次数
+	Coalesced  int64 // 在队列中被同一Key的新写入合并的次数
+	Retries    int64 // 重试次数
+	Failures   int64 // 重试耗尽后放弃的次数, write-through 模式下为写入失败次数
+	Rejected   int64 // 队列已满被拒绝的次数
+}
+
+// WithWriteThrough Set/Delete 先同步写入数据源, 成功后再更新缓存
+func WithWriteThrough(w Writer) Option {
+	return func(sc *SaberCache) {
+		sc.writer = w
+	}
+}
+
+// WithWriteTimeout 每次写入数据源(含每次重试)的超时时间, 0 表示不限制, write-through 与 write-behind 均适用
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(sc *SaberCache) {
+		if timeout < 0 {
+			timeout = 0
+		}
+		sc.writeTimeout = timeout
+	}
+}
+
+// WithWriteBehind Set/Delete 放入后台队列后立即更新缓存, 由后台协程异步写入数据源。
+// 队列最多保留 queueSize 个Key, 同一Key的多次写入合并为最后一次, 队列已满时写入被拒绝;
+// 写入失败时间隔 retryInterval(逐次翻倍, 最长 maxRetryInterval)最多重试 maxRetries 次。
+// Close 时写完队列中的数据, ctx 结束时取消进行中的写入与重试, 未写入的数据计入 Failures
+func WithWriteBehind(w Writer, queueSize, maxRetries int, retryInterval time.Duration) Option {
+	return func(sc *SaberCache) {
+		sc.writer = w
+		sc.flusher = newFlusher(w, queueSize, maxRetries, retryInterval)
+	}
+}
+
+// writeOp 待写入数据源的操作
+type writeOp struct {
+	key     string
+	value   []byte
+	deleted bool
+}
+
+// flusher write-behind 后台写入, 单协程按入队顺序写入, 保证同一Key的写入顺序
+type flusher struct {
+	writer        Writer
+	queueSize     int
+	maxRetries    int
+	retryInterval time.Duration
+	timeout       time.Duration // 每次写入的超时时间, 0 表示不限制
+	ctx           context.Context
+	cancel        context.CancelFunc // Close 的 ctx 结束时取消进行中的写入与重试
+	mu            sync.Mutex
+	cond          *sync.Cond
+	queue         *list.List // 待写入的Key, 链头最先入队
+	pending       map[string]*list.Element
+	inflight      *writeOp // 正在写入的操作
+	closed        bool
+	done          chan struct{}
+	stats         WriteStats
+}
+
+// newFlusher 创建后需调用 start 启动后台协程
+func newFlusher(w Writer, queueSize, maxRetries int, retryInterval time.Duration) *flusher {
+	f := &flusher{
+		writer:        w,
+		queueSize:     queueSize,
+		maxRetries:    maxRetries,
+		retryInterval: retryInterval,
+		queue:         list.New(),
+		pending:       make(map[string]*list.Element),
+		done:          make(chan struct{}),
+	}
+	f.cond = sync.NewCond(&f.mu)
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	return f
+}
+
+// start 以 timeout 作为每次写入的超时时间启动后台协程
+func (f *flusher) start(timeout time.Duration) {
+	f.timeout = timeout
+	go f.run()
+}
+
+// enqueue 放入写入队列, Key已在队列中时替换为新值
+func (f *flusher) enqueue(op *writeOp) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if elem, ok := f.pending[op.key]; ok {
+		elem.Value = op
+		f.stats.Coalesced++
+		return nil
+	}
+	if f.closed {
+		f.stats.Rejected++
+		return ErrWriterClosed
+	}
+	if f.queueSize > 0 && f.queue.Len() >= f.queueSize {
+		f.stats.Rejected++
+		return ErrWriteQueueFull
+	}
+	f.pending[op.key] = f.queue.PushBack(op)
+	f.cond.Signal()
+	return nil
+}
+
+// lookup 返回Key尚未写入数据源的最新操作
+func (f *flusher) lookup(key string) (*writeOp, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if elem, ok := f.pending[key]; ok {
+		return elem.Value.(*writeOp), true
+	}
+	if f.inflight != nil && f.inflight.key == key {
+		return f.inflight, true
+	}
+	return nil, false
+}
+
+func (f *flusher) run() {
+	defer close(f.done)
+	for {
+		f.mu.Lock()
+		for f.queue.Len() == 0 && !f.closed {
+			f.cond.Wait()
+		}
+		if f.ctx.Err() != nil && f.queue.Len() > 0 {
+			// Close 已超时, 放弃队列中剩余的数据
+			dropped := f.queue.Len()
+			f.stats.Failures += int64(dropped)
+			f.queue.Init()
+			f.pending = make(map[string]*list.Element)
+			f.mu.Unlock()
+			log.Printf("write-behind closed, %d keys dropped", dropped)
+			return
+		}
+		if f.queue.Len() == 0 {
+			f.mu.Unlock()
+			return
+		}
+		op := f.queue.Remove(f.queue.Front()).(*writeOp)
+		delete(f.pending, op.key)
+		f.inflight = op
+		f.mu.Unlock()
+
+		err := f.flush(op)
+
+		f.mu.Lock()
+		f.inflight = nil
+		if err != nil {
+			f.stats.Failures++
+		} else {
+			f.stats.Flushed++
+		}
+		f.mu.Unlock()
+		if err != nil {
+			log.Printf("write-behind %s failed: %v", op.key, err)
+		}
+	}
+}
+
+// flush 写入一次, 失败时按指数退避重试, f.ctx 被取消时放弃
+func (f *flusher) flush(op *writeOp) error {
+	interval := f.retryInterval
+	for attempt := 0; ; attempt++ {
+		err := write(f.ctx, f.writer, op, f.timeout)
+		if err == nil || attempt >= f.maxRetries || f.ctx.Err() != nil {
+			return err
+		}
+		f.mu.Lock()
+		f.stats.Retries++
+		f.mu.Unlock()
+		timer := time.NewTimer(interval)
+		select {
+		case <-timer.C:
+		case <-f.ctx.Done():
+			timer.Stop()
+			return err
+		}
+		if interval *= 2; interval > maxRetryInterval {
+			interval = maxRetryInterval
+		}
+	}
+}
+
+// close 停止接收写入, 等待队列中的数据写完; ctx 结束时取消进行中的写入与重试, 等待后台协程退出后返回 ctx.Err()
+func (f *flusher) close(ctx context.Context) error {
+	f.mu.Lock()
+	f.closed = true
+	f.cond.Broadcast()
+	f.mu.Unlock()
+	defer f.cancel()
+	select {
+	case <-f.done:
+		return nil
+	case <-ctx.Done():
+		f.cancel()
+		<-f.done
+		return ctx.Err()
+	}
+}
+
+func (f *flusher) Stats() WriteStats {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	stats := f.stats
+	stats.QueueDepth = f.queue.Len()
+	return stats
+}
+
+// write 以 timeout 为超时时间写入一次, timeout 为0时不限制
+func write(ctx context.Context, w Writer, op *writeOp, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	ctx, key := withNamespace(ctx, op.key)
+	if op.deleted {
+		return w.Delete(ctx, key)
+	}
+	return w.Write(ctx, key, op.value)
+}